@@ -513,6 +513,25 @@ func (u *UI) GetAnimLaser(path []geometry.Point, lightColor fxtools.HDRColor, do
 	return NewLaserAnimation(path, lightColor, done)
 }
 
+func (u *UI) GetAnimFire(positions []geometry.Point, done func()) foundation.Animation {
+	if !u.settings.AnimationsEnabled || !u.settings.AnimateEffects {
+		return nil
+	}
+	fireAnim := NewTilesAnimation(positions, []textiles.TextIcon{
+		{Char: '^', Fg: u.uiTheme.GetColorByName("Yellow_1"), Bg: u.uiTheme.GetColorByName("Orange_4")},
+		{Char: '*', Fg: u.uiTheme.GetColorByName("Orange_4"), Bg: u.uiTheme.GetColorByName("Red")},
+		{Char: '^', Fg: u.uiTheme.GetColorByName("Yellow"), Bg: u.uiTheme.GetColorByName("Orange_6")},
+		{Char: '∙', Fg: u.uiTheme.GetColorByName("Red"), Bg: u.uiTheme.GetColorByName("Orange_8")},
+	}, done)
+	fireAnim.SetLightsOnAllTiles(&gridmap.LightSource{
+		Pos:          geometry.Point{},
+		Radius:       2,
+		Color:        fxtools.NewColorFromRGBA(u.uiTheme.GetColorByName("Orange_4")),
+		MaxIntensity: 0.6,
+	})
+	return fireAnim
+}
+
 func (u *UI) GetAnimBackgroundColor(position geometry.Point, colorName string, frameCount int, done func()) foundation.Animation {
 	if !u.settings.AnimationsEnabled || !u.settings.AnimateEffects {
 		return nil
@@ -1806,7 +1825,9 @@ func (u *UI) UpdateVisibleActors() {
 		barIcon := '*'
 		if enemy.HasFlag(foundation.FlagSleep) {
 			barIcon = 'z'
-		} else if !enemy.HasFlag(foundation.FlagAwareOfPlayer) {
+		} else if enemy.GetAlertState() == foundation.AlertSuspicious {
+			barIcon = '!'
+		} else if enemy.GetAlertState() == foundation.AlertCalm {
 			barIcon = '?'
 		}
 		hpBarString := fmt.Sprintf("[%s]", u.RuneBarFromPercent(barIcon, asPercent, 5))
@@ -2025,6 +2046,9 @@ func expandToWidth(statusStr string, width int) string {
 func (u *UI) openCharSheet() {
 	charSheet := NewCharsheetViewer(u.game.GetPlayerName(), u.game.GetPlayerCharSheet(), u.closeModal)
 	charSheet.SetConfirmer(u)
+	charSheet.SetTraitChoices(u.game.GetTraitCatalogue())
+	charSheet.SetImplants(u.game.GetPlayerImplants())
+	charSheet.SetShowSkillProgress(u.game.IsLearningByDoing())
 	originalInputCapture := charSheet.GetInputCapture()
 	charSheet.SetInputCapture(u.directionalWrapper(originalInputCapture))
 
@@ -2034,6 +2058,7 @@ func (u *UI) openInventory(items []foundation.Item) *TextInventory {
 	inventory := NewTextInventory(u.game.IsPlayerOverEncumbered)
 	inventory.SetLineColor(u.uiTheme.GetInventoryItemColor)
 	inventory.SetEquippedTest(u.game.IsEquipped)
+	inventory.SetBeltIndex(u.game.GetBeltIndex)
 	inventory.SetStyle(u.uiTheme.defaultStyle)
 
 	inventory.SetItems(items)
@@ -2063,6 +2088,8 @@ func (u *UI) OpenInventoryForManagement(items []foundation.Item) {
 	})
 	inv.SetShiftSelection(u.game.DropItemFromInventory)
 	inv.SetControlSelection(u.game.PlayerApplyItem)
+	inv.SetBeltSelection(u.game.BeltToggle)
+	inv.SetOffHandSelection(u.game.EquipToOffHand)
 
 	inv.SetCloseOnControlSelection(true)
 	inv.SetCloseOnShiftSelection(true)
@@ -2256,6 +2283,25 @@ func (u *UI) StartHackingGame(identifier uint64, difficulty foundation.Difficult
 	u.lockFocusToPrimitive(hackingGame)
 }
 
+func (u *UI) OpenTerminal(title string, greeting []string, execute func(command string) []string) {
+	terminalScreen := NewTerminalScreen(title, greeting, execute, u.CloseTerminal)
+	terminalScreen.SetAudioPlayer(u.audioPlayer)
+	u.audioPlayer.PlayCue("ui/terminal_poweron")
+
+	u.pages.AddPanel("terminal", terminalScreen, true, true)
+	u.pages.ShowPanel("terminal")
+	u.lockFocusToPrimitive(terminalScreen)
+}
+
+func (u *UI) CloseTerminal() {
+	if !u.pages.HasPanel("terminal") {
+		return
+	}
+	u.audioPlayer.PlayCue("ui/terminal_poweroff")
+	u.pages.RemovePanel("terminal")
+	u.resetFocusToMain()
+}
+
 func (u *UI) SetConversationState(starterText string, starterOptions []foundation.MenuItem, chatterSource foundation.ChatterSource, isTerminal bool) {
 	u.dialogueIsTerminal = isTerminal
 
@@ -2373,6 +2419,12 @@ func (u *UI) OpenMenuWithTitle(title string, actions []foundation.MenuItem) {
 	menu.SetTitle(title)
 }
 
+// OpenMenuWithTitleNotClosable is for choices that can't be skipped, the player has to select one of the items.
+func (u *UI) OpenMenuWithTitleNotClosable(title string, actions []foundation.MenuItem) {
+	menu := u.openSimpleMenuNotClosable(actions)
+	menu.SetTitle(title)
+}
+
 func (u *UI) OpenMenu(actions []foundation.MenuItem) {
 	u.openSimpleMenu(actions)
 }
@@ -2679,6 +2731,16 @@ func (u *UI) ShowItemOverlay() {
 	}
 }
 
+// ShowMapOverlay draws the given icons on top of the map until the next key press.
+func (u *UI) ShowMapOverlay(icons map[geometry.Point]textiles.TextIcon) {
+	u.mapOverlay.ClearAll()
+	for pos, icon := range icons {
+		if u.mapOverlay.Contains(pos.X, pos.Y) {
+			u.mapOverlay.Set(pos.X, pos.Y, icon)
+		}
+	}
+}
+
 func (u *UI) ShowVisibleActors() {
 	listOfEnemies := u.game.GetVisibleActors()
 	if len(listOfEnemies) == 0 {
@@ -2944,6 +3006,7 @@ func (u *UI) visibleLookup(loc geometry.Point) (textiles.TextIcon, bool) {
 	default:
 		icon = u.getIconForMap(loc)
 	}
+	icon = u.applyFields(loc, icon, entityType == foundation.EntityTypeWorldTile)
 	fgWithLight, bgWithLight := u.ApplyLighting(loc, icon.Fg, icon.Bg)
 	icon.Fg = fgWithLight
 	icon.Bg = bgWithLight
@@ -0,0 +1,44 @@
+package console
+
+import (
+	"RogueUI/gridmap"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/textiles"
+)
+
+var fireRunes = []rune{'^', '*', '^', '∙'}
+
+// applyFields tints the icon at the given position with the smoke, gas and fire on it.
+// The rune is only replaced if nothing else (actor, item, object) is drawn there.
+func (u *UI) applyFields(loc geometry.Point, icon textiles.TextIcon, isWorldTile bool) textiles.TextIcon {
+	fire, smoke, gas := u.game.FieldsAt(loc)
+
+	if gas > 0 {
+		gasColor := u.uiTheme.GetColorByName("neon_green_3")
+		icon.Bg = fxtools.LerpColorRGBA(icon.Bg, gasColor, fieldOpacity(gas))
+	}
+
+	if smoke > 0 {
+		smokeColor := u.uiTheme.GetColorByName("gray_6")
+		opacity := fieldOpacity(smoke)
+		icon.Bg = fxtools.LerpColorRGBA(icon.Bg, smokeColor, opacity)
+		icon.Fg = fxtools.LerpColorRGBA(icon.Fg, smokeColor, opacity)
+		if isWorldTile && smoke >= gridmap.SmokeOpaqueIntensity {
+			icon.Char = '▒'
+		}
+	}
+
+	if fire > 0 {
+		icon.Bg = fxtools.LerpColorRGBA(icon.Bg, u.uiTheme.GetColorByName("Orange_6"), fieldOpacity(fire))
+		if isWorldTile {
+			icon.Char = fireRunes[(loc.X+loc.Y+fire)%len(fireRunes)]
+			icon.Fg = u.uiTheme.GetColorByName("Yellow_1")
+		}
+	}
+	return icon
+}
+
+func fieldOpacity(intensity int) float64 {
+	return fxtools.Clamp(0.2, 0.8, float64(intensity)/float64(gridmap.MaxFieldIntensity))
+}
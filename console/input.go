@@ -143,6 +143,11 @@ func (u *UI) setupCommandTable() {
 	u.commandTable["cycle_target_mode"] = u.game.CycleTargetMode
 	u.commandTable["apply_skill"] = u.game.PlayerApplySkill
 	u.commandTable["reload_weapon"] = u.game.PlayerReloadWeapon
+	u.commandTable["swap_hands"] = u.game.PlayerSwapHands
+	u.commandTable["use_belt_1"] = func() { u.game.PlayerUseBeltItem(0) }
+	u.commandTable["use_belt_2"] = func() { u.game.PlayerUseBeltItem(1) }
+	u.commandTable["use_belt_3"] = func() { u.game.PlayerUseBeltItem(2) }
+	u.commandTable["use_belt_4"] = func() { u.game.PlayerUseBeltItem(3) }
 
 	//u.commandTable["targeted_shot"] = u.game.TargetedShot
 
@@ -150,6 +155,7 @@ func (u *UI) setupCommandTable() {
 	u.commandTable["map_interaction"] = u.GenericInteraction
 	u.commandTable["run_direction"] = u.ChooseDirectionForRun
 	u.commandTable["wait"] = u.game.Wait
+	u.commandTable["search"] = u.game.PlayerSearch
 	u.commandTable["show_key_bindings"] = u.showKeyBindings
 	u.commandTable["open_pip_boy"] = u.openPipBoy
 
@@ -205,9 +211,15 @@ func (u *UI) showKeyBindings() {
 		"map_interaction":   "Map Interaction",
 		"run_direction":     "Run Direction",
 		"wait":              "Wait",
+		"search":            "Search",
 		"cycle_target_mode": "Cycle Weapon Mode",
 		"apply_skill":       "Apply Skill",
 		"reload_weapon":     "Reload Weapon",
+		"swap_hands":        "Swap Hands",
+		"use_belt_1":        "Use Belt Slot 1",
+		"use_belt_2":        "Use Belt Slot 2",
+		"use_belt_3":        "Use Belt Slot 3",
+		"use_belt_4":        "Use Belt Slot 4",
 		"apply":             "Apply",
 	}
 
@@ -241,11 +253,17 @@ func (u *UI) showKeyBindings() {
 	rightColCommands := []string{
 		"map_interaction",
 		"wait",
+		"search",
 		"throw",
 		"drop",
 		"pickup",
 		"cycle_target_mode",
 		"reload_weapon",
+		"swap_hands",
+		"use_belt_1",
+		"use_belt_2",
+		"use_belt_3",
+		"use_belt_4",
 		"attack",
 		"quick_attack",
 		"look",
@@ -23,6 +23,9 @@ type TextInventory struct {
 	listHeight             int
 	closeHandler           func()
 	isEquipped             func(item foundation.Item) bool
+	beltIndex              func(item foundation.Item) (int, bool)
+	beltSelection          func(item foundation.Item, index int)
+	offHandSelection       func(item foundation.Item)
 	style                  tcell.Style
 	closeOnSelect          bool
 	closeOnShiftSelect     bool
@@ -65,6 +68,9 @@ func (i *TextInventory) drawInside(screen tcell.Screen, x int, y int, width int,
 		}
 		if i.isEquipped != nil && i.isEquipped(item) {
 			line = line[:2] + "+" + line[3:]
+			if beltIndex, isOnBelt := i.getBeltIndex(item); isOnBelt {
+				line = line[:2] + fmt.Sprint(beltIndex+1) + line[3:]
+			}
 		}
 		drawX := startX + listOffset.X
 		drawY := startY + listOffset.Y + lineIndex
@@ -125,6 +131,12 @@ func (i *TextInventory) drawInside(screen tcell.Screen, x int, y int, width int,
 	if len(dropRunes) > 0 {
 		infoLines = append(infoLines, cview.Escape(fmt.Sprintf("[%s] Drop", strings.ToUpper(string(dropRunes)))))
 	}
+	if i.beltSelection != nil {
+		infoLines = append(infoLines, cview.Escape(fmt.Sprintf("[1-%d] Belt (cursor)", len(foundation.BeltSlots))))
+	}
+	if i.offHandSelection != nil {
+		infoLines = append(infoLines, cview.Escape("[0] Off hand (cursor)"))
+	}
 
 	additionalLines := len(infoLines)
 
@@ -208,6 +220,27 @@ func (i *TextInventory) SetEquippedTest(isEquipped func(item foundation.Item) bo
 	i.isEquipped = isEquipped
 }
 
+// SetBeltIndex marks the items on the belt with the number of their quick-slot.
+func (i *TextInventory) SetBeltIndex(beltIndex func(item foundation.Item) (int, bool)) {
+	i.beltIndex = beltIndex
+}
+
+func (i *TextInventory) getBeltIndex(item foundation.Item) (int, bool) {
+	if i.beltIndex == nil {
+		return 0, false
+	}
+	return i.beltIndex(item)
+}
+
+// SetBeltSelection is called with the item under the cursor, when a number key is pressed.
+func (i *TextInventory) SetBeltSelection(onSelect func(item foundation.Item, index int)) {
+	i.beltSelection = onSelect
+}
+
+func (i *TextInventory) SetOffHandSelection(onSelect func(item foundation.Item)) {
+	i.offHandSelection = onSelect
+}
+
 func (i *TextInventory) SetCloseHandler(escapeHandler func()) {
 	i.closeHandler = escapeHandler
 }
@@ -243,6 +276,18 @@ func (i *TextInventory) handleInput(event *tcell.EventKey) *tcell.EventKey {
 		}
 	}
 
+	hasCursorItem := i.cursorAtIndex >= 0 && i.cursorAtIndex < len(i.items)
+	if hasCursorItem && i.beltSelection != nil && event.Rune() >= '1' && event.Rune() < '1'+rune(len(foundation.BeltSlots)) {
+		i.beltSelection(i.items[i.cursorAtIndex], int(event.Rune()-'1'))
+		i.updateListBounds()
+		return nil
+	}
+	if hasCursorItem && i.offHandSelection != nil && event.Rune() == '0' {
+		i.offHandSelection(i.items[i.cursorAtIndex])
+		i.updateListBounds()
+		return nil
+	}
+
 	runeReceived := event.Rune()
 	// to upper
 	modCtrl := event.Modifiers() == tcell.ModAlt || event.Modifiers() == tcell.ModCtrl || event.Modifiers() == tcell.ModMeta
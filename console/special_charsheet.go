@@ -37,6 +37,11 @@ type CharsheetViewer struct {
 
 	virtuallySpentSkillPoints map[special.Skill]int
 	virtualFocus              int
+
+	traitChoices []special.Trait
+	implants     []string
+
+	showSkillProgress bool
 }
 
 type Confirmer interface {
@@ -77,6 +82,24 @@ func (c *CharsheetViewer) SetConfirmer(conf Confirmer) {
 	c.conf = conf
 }
 
+// SetTraitChoices sets the traits the player can pick from during character creation.
+func (c *CharsheetViewer) SetTraitChoices(traits []special.Trait) {
+	c.traitChoices = traits
+	c.updateUIFromSheet()
+}
+
+// SetImplants sets the descriptions of the installed implants, they are listed below the traits.
+func (c *CharsheetViewer) SetImplants(implants []string) {
+	c.implants = implants
+	c.updateUIFromSheet()
+}
+
+// SetShowSkillProgress adds a progress bar towards the next point to each skill, used when learning by doing.
+func (c *CharsheetViewer) SetShowSkillProgress(show bool) {
+	c.showSkillProgress = show
+	c.updateUIFromSheet()
+}
+
 func (c *CharsheetViewer) SetMode() {
 	c.mode = ModeView
 	if c.sheet.HasStatPointsToSpend() || c.sheet.GetTagSkillCount() < 3 {
@@ -271,6 +294,30 @@ func (c *CharsheetViewer) setupUI() {
 	traitsList.ShowSecondaryText(false)
 	traitsList.SetScrollBarVisibility(cview.ScrollBarNever)
 	traitsList.SetHighlightDisabled(true)
+	traitsList.SetMouseCapture(func(action cview.MouseAction, event *tcell.EventMouse) (cview.MouseAction, *tcell.EventMouse) {
+		if action == cview.MouseLeftClick {
+			_, y := event.Position()
+			// to local coordinates
+			_, startY, _, _ := traitsList.GetInnerRect()
+			// to list item index
+			i := y - startY
+
+			if c.mode == ModeCreate {
+				if i < 0 || i >= len(c.traitChoices) {
+					return action, event
+				}
+				c.toggleTrait(i)
+				return action, event
+			}
+
+			traits := c.sheet.GetTraits()
+			if i < 0 || i >= len(traits) {
+				return action, event
+			}
+			c.descriptionWindow.SetText(traitToString(traits[i]))
+		}
+		return action, event
+	})
 
 	buttonBar := cview.NewGrid()
 	buttonBar.SetColumns(0, 0)
@@ -345,6 +392,22 @@ func (c *CharsheetViewer) toggleTagSkill(skill special.Skill) {
 	c.skillList.SetCurrentItem(int(skill))
 }
 
+func (c *CharsheetViewer) toggleTrait(index int) {
+	trait := c.traitChoices[index]
+	if c.sheet.HasTrait(trait.Name) {
+		c.sheet.RemoveTrait(trait.Name)
+	} else {
+		c.sheet.AddTrait(trait)
+	}
+	c.updateUIFromSheet()
+	c.traitsList.SetCurrentItem(index)
+	c.descriptionWindow.SetText(traitToString(trait))
+}
+
+func traitToString(trait special.Trait) string {
+	return trait.Name + "\n" + trait.Description + "\n\n" + strings.Join(trait.Effects(), "\n")
+}
+
 func (c *CharsheetViewer) Close() {
 	c.close()
 }
@@ -380,6 +443,7 @@ func (c *CharsheetViewer) updateUIFromSheet() {
 		tableRowsForCreateInfo := []fxtools.TableRow{
 			{Columns: []string{"Stat Points:", strconv.Itoa(c.sheet.GetStatPointsToSpend())}},
 			{Columns: []string{"Tag Skills:", fmt.Sprintf("%d/3", c.sheet.GetTagSkillCount())}},
+			{Columns: []string{"Traits:", fmt.Sprintf("%d/%d", c.sheet.GetTraitCount(), special.MaxTraits)}},
 		}
 		infoLines = fxtools.TableLayout(tableRowsForCreateInfo, []fxtools.TextAlignment{fxtools.AlignLeft, fxtools.AlignRight})
 	} else if c.sheet.HasSkillPointsToSpend() {
@@ -457,6 +521,10 @@ func (c *CharsheetViewer) updateUIFromSheet() {
 			valueColumn,
 		}
 
+		if c.showSkillProgress && c.mode != ModeCreate {
+			skillColumns = append(skillColumns, skillProgressBar(c.sheet.GetSkillProgress(skill)))
+		}
+
 		if c.mode == ModeCreate {
 			tagIcon := cview.Escape("[ ]")
 			if isTagged {
@@ -482,6 +550,9 @@ func (c *CharsheetViewer) updateUIFromSheet() {
 	}
 
 	alignments := []fxtools.TextAlignment{fxtools.AlignLeft, fxtools.AlignRight}
+	if c.showSkillProgress && c.mode != ModeCreate {
+		alignments = append(alignments, fxtools.AlignLeft)
+	}
 	if c.mode == ModeCreate || c.sheet.HasSkillPointsToSpend() {
 		alignments = append(alignments, fxtools.AlignRight)
 	}
@@ -525,8 +596,28 @@ func (c *CharsheetViewer) updateUIFromSheet() {
 
 	*/
 	c.traitsList.Clear()
-	c.traitsList.AddItem(cview.NewListItem("No Traits"))
+	if c.mode == ModeCreate {
+		for _, trait := range c.traitChoices {
+			traitLine := cview.Escape("[ ] ") + trait.Name
+			if c.sheet.HasTrait(trait.Name) {
+				traitLine = "[black:yellow:]" + cview.Escape("[X] ") + trait.Name
+			}
+			c.traitsList.AddItem(cview.NewListItem(traitLine))
+		}
+	} else {
+		traits := c.sheet.GetTraits()
+		for _, trait := range traits {
+			c.traitsList.AddItem(cview.NewListItem("[yellow:black:]" + trait.Name))
+		}
+		if len(traits) == 0 {
+			c.traitsList.AddItem(cview.NewListItem("No Traits"))
+		}
+	}
+	// the trait choices come first, so their list indices stay the same
 	c.traitsList.AddItem(cview.NewListItem("No Perks"))
+	for _, implant := range c.implants {
+		c.traitsList.AddItem(cview.NewListItem("[aqua:black:]" + cview.Escape(implant)))
+	}
 }
 
 func (c *CharsheetViewer) getSkillPointsAvailable() int {
@@ -579,11 +670,23 @@ func (c *CharsheetViewer) moveSelectionVertically(direction int) {
 				c.focusStats(int(special.StatCount) - 1)
 			}
 		} else if c.virtualFocus == 1 { // stats selected
-			newIndex := (c.statList.GetCurrentItemIndex() + direction) % int(special.StatCount)
-			c.statList.SetCurrentItem(newIndex)
+			newIndex := c.statList.GetCurrentItemIndex() + direction
+			if newIndex >= int(special.StatCount) && len(c.traitChoices) > 0 {
+				c.focusTraits(0)
+				return
+			}
+			c.statList.SetCurrentItem(newIndex % int(special.StatCount))
 		} else if c.virtualFocus == 2 { // skills selected
 			newIndex := (c.skillList.GetCurrentItemIndex() + direction) % int(special.SkillCount)
 			c.skillList.SetCurrentItem(newIndex)
+		} else if c.virtualFocus == 3 { // traits selected
+			newIndex := c.traitsList.GetCurrentItemIndex() + direction
+			if newIndex < 0 {
+				c.focusStats(int(special.StatCount) - 1)
+				return
+			}
+			c.traitsList.SetCurrentItem(newIndex % len(c.traitChoices))
+			c.descriptionWindow.SetText(traitToString(c.traitChoices[c.traitsList.GetCurrentItemIndex()]))
 		}
 		return
 	}
@@ -628,6 +731,13 @@ func (c *CharsheetViewer) moveSelectionHorizontally(direction int) {
 				// stats
 				c.focusStats(0)
 			}
+		} else if c.virtualFocus == 3 {
+			// traits selected
+			if direction > 0 {
+				c.focusSkills(0)
+			} else {
+				c.focusStats(0)
+			}
 		}
 
 		return
@@ -651,6 +761,7 @@ func (c *CharsheetViewer) focusStats(selectedIndex int) {
 	c.statList.SetCurrentItem(selectedIndex)
 
 	c.skillList.SetHighlightDisabled(true)
+	c.traitsList.SetHighlightDisabled(true)
 }
 func (c *CharsheetViewer) focusSkills(index int) {
 	c.virtualFocus = 2
@@ -658,6 +769,16 @@ func (c *CharsheetViewer) focusSkills(index int) {
 	c.skillList.SetCurrentItem(index)
 
 	c.statList.SetHighlightDisabled(true)
+	c.traitsList.SetHighlightDisabled(true)
+}
+func (c *CharsheetViewer) focusTraits(index int) {
+	c.virtualFocus = 3
+	c.traitsList.SetHighlightDisabled(false)
+	c.traitsList.SetCurrentItem(index)
+	c.descriptionWindow.SetText(traitToString(c.traitChoices[index]))
+
+	c.statList.SetHighlightDisabled(true)
+	c.skillList.SetHighlightDisabled(true)
 }
 
 func (c *CharsheetViewer) confirmSelection() {
@@ -672,6 +793,14 @@ func (c *CharsheetViewer) confirmSelection() {
 			index := c.skillList.GetCurrentItemIndex()
 			selectedSkill := special.Skill(index)
 			c.toggleTagSkill(selectedSkill)
+		} else if c.virtualFocus == 3 {
+			c.toggleTrait(c.traitsList.GetCurrentItemIndex())
 		}
 	}
 }
+
+func skillProgressBar(percent int) string {
+	const width = 5
+	filled := min(width, percent*width/100)
+	return strings.Repeat("#", filled) + strings.Repeat(".", width-filled)
+}
@@ -0,0 +1,180 @@
+package console
+
+import (
+	"RogueUI/foundation"
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/memmaker/go/cview"
+	"strings"
+)
+
+// TerminalScreen is a green-screen command line for the computer terminals in the world.
+// Every entered line is passed to the game, the answer is appended to the scroll buffer.
+type TerminalScreen struct {
+	*cview.Box
+	style        tcell.Style
+	title        string
+	lines        []string
+	input        []rune
+	history      []string
+	historyIndex int
+	scrollOffset int
+	execute      func(command string) []string
+	closeFunc    func()
+	borders      cview.BorderDef
+	audioPlayer  foundation.AudioCuePlayer
+}
+
+func NewTerminalScreen(title string, greeting []string, execute func(command string) []string, close func()) *TerminalScreen {
+	box := cview.NewBox()
+	greenStyle := tcell.StyleDefault.Foreground(tcell.ColorForestGreen).Background(tcell.ColorBlack)
+	t := &TerminalScreen{
+		Box:       box,
+		style:     greenStyle,
+		title:     title,
+		lines:     greeting,
+		execute:   execute,
+		closeFunc: close,
+		borders: cview.BorderDef{
+			Horizontal:  '─',
+			Vertical:    '│',
+			TopLeft:     '╭',
+			TopRight:    '╮',
+			BottomLeft:  '╰',
+			BottomRight: '╯',
+		},
+	}
+
+	box.SetBorder(false)
+	box.SetDrawFunc(t.drawInside)
+	box.SetBackgroundTransparent(false)
+	box.SetInputCapture(t.handleInput)
+	return t
+}
+
+func (t *TerminalScreen) SetAudioPlayer(player foundation.AudioCuePlayer) {
+	t.audioPlayer = player
+}
+
+func (t *TerminalScreen) playCue(cue string) {
+	if t.audioPlayer != nil {
+		t.audioPlayer.PlayCue(cue)
+	}
+}
+
+func (t *TerminalScreen) drawInside(screen tcell.Screen, x int, y int, width int, height int) (int, int, int, int) {
+	if width < 20 || height < 6 {
+		return x, y, width, height
+	}
+	cview.DrawBox(screen, x, y, x+width-1, y+height-1, t.style, t.borders, ' ')
+
+	title := " " + t.title + " "
+	printToScreen(screen, x+(width-runewidth.StringWidth(title))/2, y, title, t.style.Reverse(true))
+
+	innerX := x + 2
+	innerWidth := width - 4
+	outputHeight := height - 4
+
+	var wrapped []string
+	for _, line := range t.lines {
+		wrapped = append(wrapped, wrapTerminalLine(line, innerWidth)...)
+	}
+
+	t.scrollOffset = max(0, min(t.scrollOffset, len(wrapped)-outputHeight))
+	lastLine := len(wrapped) - t.scrollOffset
+	firstLine := max(0, lastLine-outputHeight)
+	for i, line := range wrapped[firstLine:lastLine] {
+		printToScreen(screen, innerX, y+1+i, line, t.style)
+	}
+
+	if t.scrollOffset > 0 {
+		printToScreen(screen, x+width-3, y+1, "▲", t.style)
+	}
+
+	promptY := y + height - 2
+	printToScreen(screen, innerX, promptY-1, strings.Repeat("─", innerWidth), t.style)
+	prompt := "> " + string(t.input)
+	if overflow := runewidth.StringWidth(prompt) - (innerWidth - 1); overflow > 0 {
+		prompt = string([]rune(prompt)[overflow:])
+	}
+	printToScreen(screen, innerX, promptY, prompt, t.style)
+	screen.SetContent(innerX+runewidth.StringWidth(prompt), promptY, '█', nil, t.style)
+
+	return x, y, width, height
+}
+
+func (t *TerminalScreen) handleInput(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEsc:
+		t.closeFunc()
+	case tcell.KeyEnter:
+		t.submit()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(t.input) > 0 {
+			t.input = t.input[:len(t.input)-1]
+		}
+	case tcell.KeyUp:
+		if t.historyIndex > 0 {
+			t.historyIndex--
+			t.input = []rune(t.history[t.historyIndex])
+		}
+	case tcell.KeyDown:
+		if t.historyIndex < len(t.history)-1 {
+			t.historyIndex++
+			t.input = []rune(t.history[t.historyIndex])
+		} else {
+			t.historyIndex = len(t.history)
+			t.input = nil
+		}
+	case tcell.KeyPgUp:
+		t.scrollOffset += 5
+	case tcell.KeyPgDn:
+		t.scrollOffset = max(0, t.scrollOffset-5)
+	case tcell.KeyRune:
+		t.input = append(t.input, event.Rune())
+		t.playCue("ui/hacking_updown")
+	}
+	return nil
+}
+
+func (t *TerminalScreen) submit() {
+	command := strings.TrimSpace(string(t.input))
+	t.input = nil
+	t.scrollOffset = 0
+	t.lines = append(t.lines, "> "+command)
+	if command == "" {
+		return
+	}
+	t.history = append(t.history, command)
+	t.historyIndex = len(t.history)
+	t.playCue("ui/hacking_confirm")
+	t.lines = append(t.lines, t.execute(command)...)
+}
+
+func wrapTerminalLine(line string, width int) []string {
+	if runewidth.StringWidth(line) <= width {
+		return []string{line}
+	}
+	var result []string
+	var current string
+	for _, word := range strings.Split(line, " ") {
+		for runewidth.StringWidth(word) > width {
+			if current != "" {
+				result = append(result, current)
+				current = ""
+			}
+			cut := runewidth.Truncate(word, width, "")
+			result = append(result, cut)
+			word = strings.TrimPrefix(word, cut)
+		}
+		if current == "" {
+			current = word
+		} else if runewidth.StringWidth(current)+1+runewidth.StringWidth(word) <= width {
+			current += " " + word
+		} else {
+			result = append(result, current)
+			current = word
+		}
+	}
+	return append(result, current)
+}
@@ -18,6 +18,7 @@ type ActorForUI interface {
 	GetHitPointsMax() int
 	HasFlag(held ActorFlag) bool
 	GetState() AIState
+	GetAlertState() AlertState
 	GetDetailInfo() string
 	GetInternalName() string
 	IsAlive() bool
@@ -60,6 +61,15 @@ const (
 	Panic
 )
 
+// AlertState describes how much an NPC suspects that something is wrong.
+type AlertState uint8
+
+const (
+	AlertCalm AlertState = iota
+	AlertSuspicious
+	AlertAlerted
+)
+
 func AIStateFromString(str string) AIState {
 	str = strings.ToLower(str)
 	switch str {
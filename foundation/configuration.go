@@ -30,6 +30,11 @@ type Configuration struct {
 	DialogueShortcutsAreNumbers bool
 	UseLockpickingMiniGame      bool
 	UseLockpickingDX            bool
+	SurvivalMode                bool
+	LearnByDoing                bool
+	// LearnByDoingRatio is the share of the skill growth in percent, that comes from using the skills.
+	// The skill points from level ups are reduced by the same share.
+	LearnByDoingRatio int
 
 	AudioEnabled        bool
 	MusicEnabled        bool
@@ -95,6 +100,12 @@ func NewConfigurationFromFile(file string) *Configuration {
 			configuration.UseLockpickingMiniGame = field.AsBool()
 		case "UseLockpickingDX":
 			configuration.UseLockpickingDX = field.AsBool()
+		case "SurvivalMode":
+			configuration.SurvivalMode = field.AsBool()
+		case "LearnByDoing":
+			configuration.LearnByDoing = field.AsBool()
+		case "LearnByDoingRatio":
+			configuration.LearnByDoingRatio = field.AsInt()
 		}
 	}
 	return configuration
@@ -123,6 +134,9 @@ func NewDefaultConfiguration() *Configuration {
 		DialogueShortcutsAreNumbers: false,
 		UseLockpickingMiniGame:      false,
 		UseLockpickingDX:            false,
+		SurvivalMode:                false,
+		LearnByDoing:                false,
+		LearnByDoingRatio:           50,
 		AudioEnabled:                true,
 		MusicEnabled:                true,
 		SoundEffectsEnabled:         true,
@@ -159,6 +173,9 @@ func (c *Configuration) WriteToFile(filename string) {
 			recfile.Field{Name: "DialogueShortcutsAreNumbers", Value: recfile.BoolStr(c.DialogueShortcutsAreNumbers)},
 			recfile.Field{Name: "UseLockpickingMiniGame", Value: recfile.BoolStr(c.UseLockpickingMiniGame)},
 			recfile.Field{Name: "UseLockpickingDX", Value: recfile.BoolStr(c.UseLockpickingDX)},
+			recfile.Field{Name: "SurvivalMode", Value: recfile.BoolStr(c.SurvivalMode)},
+			recfile.Field{Name: "LearnByDoing", Value: recfile.BoolStr(c.LearnByDoing)},
+			recfile.Field{Name: "LearnByDoingRatio", Value: recfile.IntStr(c.LearnByDoingRatio)},
 		},
 	}
 	file, _ := os.Create(filename)
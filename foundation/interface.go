@@ -29,6 +29,8 @@ type GameForUI interface {
 
 	PlayerPickupItem()
 	EquipToggle(item Item)
+	EquipToOffHand(item Item)
+	BeltToggle(item Item, index int)
 	DropItemFromInventory(item Item)
 	PlayerApplyItem(item Item)
 	PlayerToggleRun()
@@ -40,6 +42,9 @@ type GameForUI interface {
 	PlayerReloadWeapon()
 	CycleTargetMode()
 	PlayerApplySkill()
+	PlayerUseBeltItem(index int)
+	PlayerSwapHands()
+	PlayerSearch()
 
 	CheckTransition() // up/down stairs..
 	PlayerInteractInDirection(direction geometry.CompassDirection)
@@ -58,6 +63,9 @@ type GameForUI interface {
 	IsPlayerAndMapInitialized() bool
 	GetPlayerName() string
 	GetPlayerCharSheet() *special.CharSheet
+	GetTraitCatalogue() []special.Trait
+	GetPlayerImplants() []string
+	IsLearningByDoing() bool
 	GetPlayerPosition() geometry.Point
 	GetCharacterSheet() string
 	IsPlayerOverEncumbered() bool
@@ -69,6 +77,7 @@ type GameForUI interface {
 	GetHudFlags() map[ActorFlag]int
 	GetMapInfo(pos geometry.Point) HiLiteString
 	LightAt(p geometry.Point) fxtools.HDRColor
+	FieldsAt(p geometry.Point) (fire, smoke, gas int)
 
 	GetInventoryForUI() []Item
 
@@ -101,6 +110,7 @@ type GameForUI interface {
 	ChooseArmorToTakeOff()
 
 	IsEquipped(item Item) bool
+	GetBeltIndex(item Item) (int, bool)
 
 	// Game State
 	Reset()
@@ -170,12 +180,14 @@ type GameUI interface {
 	ShowTextFileFullscreen(filename string, onClose func())
 	OpenMenu(actions []MenuItem)
 	OpenMenuWithTitle(title string, actions []MenuItem)
+	OpenMenuWithTitleNotClosable(title string, actions []MenuItem)
 	OpenKeypad(correctSequence []rune, onCompletion func(success bool))
 	OpenVendorMenu(itemsForSale []fxtools.Tuple[Item, int], buyItem func(ui Item, price int))
 	ShowGameOver(score ScoreInfo, highScores []ScoreInfo)
 	ShowTakeOnlyContainer(name string, containedItems []Item, transfer func(ui Item))
 	ShowGiveAndTakeContainer(leftName string, leftItems []Item, rightName string, rightItems []Item, transferToLeft func(itemTaken Item, amount int), transferToRight func(itemTaken Item, amount int))
 	OpenAimedShotPicker(actorAt ActorForUI, previousAim special.BodyPart, onSelected func(victim ActorForUI, hitZone special.BodyPart))
+	ShowMapOverlay(icons map[geometry.Point]textiles.TextIcon)
 
 	SaveGame()
 	LoadGame()
@@ -218,11 +230,14 @@ type GameUI interface {
 	GetAnimWakeUp(position geometry.Point, done func()) Animation
 	GetAnimEvade(defender ActorForUI, done func()) Animation
 	GetAnimLaser(path []geometry.Point, lightColor fxtools.HDRColor, done func()) Animation
+	GetAnimFire(positions []geometry.Point, done func()) Animation
 
 	PlayMusic(fileName string)
 	PlayCue(cue string)
 	SetConversationState(text string, options []MenuItem, conversationPartner ChatterSource, isTerminal bool)
 	CloseConversation()
+	OpenTerminal(title string, greeting []string, execute func(command string) []string)
+	CloseTerminal()
 	StartHackingGame(identifier uint64, difficulty Difficulty, previousGuesses []string, onCompletion func(previousGuesses []string, success InteractionResult))
 	StartLockpickGame(difficulty Difficulty, getLockpickCount func() int, removeLockpick func(), onCompletion func(result InteractionResult))
 	SetColors(palette textiles.ColorPalette, colors map[ItemCategory]color.RGBA)
@@ -308,6 +323,23 @@ func (d Difficulty) String() string {
 	return "Unknown"
 }
 
+// LowerString is the reversal of DifficultyFromString
+func (d Difficulty) LowerString() string {
+	switch d {
+	case VeryEasy:
+		return "veryeasy"
+	case Easy:
+		return "easy"
+	case Medium:
+		return "medium"
+	case Hard:
+		return "hard"
+	case VeryHard:
+		return "veryhard"
+	}
+	return "medium"
+}
+
 func (d Difficulty) GetRollModifier() int {
 	switch d {
 	case VeryEasy:
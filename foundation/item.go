@@ -47,6 +47,8 @@ type Equippable interface {
 	IsMeleeWeapon() bool
 	IsMissile() bool
 	GetEquipFlag() ActorFlag
+	GetEquipSlot() EquipSlot
+	IsWatch() bool
 	Charges() int
 	AfterEquippedTurn()
 	InternalName() string
@@ -100,6 +102,9 @@ type Item interface {
 	IsKey() bool
 	IsWatch() bool
 	IsFood() bool
+	IsAccessory() bool
+	CanBePutOnBelt() bool
+	GetEquipSlot() EquipSlot
 
 	// Stacking
 	IsMultipleStacks() bool
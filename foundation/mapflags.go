@@ -81,6 +81,38 @@ func (f ActorFlag) String() string { // Nice strings for display
         return "Turns Since Last Idle Chatter"
     case FlagConcentratedAiming:
         return "Concentrated Aiming"
+    case FlagGasProtection:
+        return "Gas Protection"
+    case FlagBegging:
+        return "Begging"
+    case FlagSurrendered:
+        return "Surrendered"
+    case FlagMechanical:
+        return "Mechanical"
+    case FlagStationary:
+        return "Stationary"
+    case FlagShutDown:
+        return "Shut Down"
+    case FlagEMPDisabled:
+        return "EMP Disabled"
+    case FlagBleeding:
+        return "Bleeding"
+    case FlagFirstAidUses:
+        return "First Aid Uses"
+    case FlagAddicted:
+        return "Addicted"
+    case FlagWithdrawal:
+        return "Withdrawal"
+    case FlagThirst:
+        return "Thirst"
+    case FlagTurnsSinceDrinking:
+        return "Turns Since Drinking"
+    case FlagFatigue:
+        return "Fatigue"
+    case FlagTurnsSinceSleeping:
+        return "Turns Since Sleeping"
+    case FlagDazzled:
+        return "Dazzled"
     case FlagCount:
         return "Count"
     }
@@ -151,6 +183,38 @@ func (f ActorFlag) StringShort() string { // short abbreviated strings (2-3 lett
         return "Run"
     case FlagConcentratedAiming:
         return "CAm"
+    case FlagGasProtection:
+        return "GsP"
+    case FlagBegging:
+        return "Beg"
+    case FlagSurrendered:
+        return "Srn"
+    case FlagMechanical:
+        return "Mec"
+    case FlagStationary:
+        return "Sta"
+    case FlagShutDown:
+        return "Off"
+    case FlagEMPDisabled:
+        return "EMP"
+    case FlagBleeding:
+        return "Bld"
+    case FlagFirstAidUses:
+        return "FAU"
+    case FlagAddicted:
+        return "Add"
+    case FlagWithdrawal:
+        return "Wdl"
+    case FlagThirst:
+        return "Thr"
+    case FlagTurnsSinceDrinking:
+        return "TSD"
+    case FlagFatigue:
+        return "Ftg"
+    case FlagTurnsSinceSleeping:
+        return "TSS"
+    case FlagDazzled:
+        return "Dzl"
     }
     return "Unk"
 
@@ -218,6 +282,22 @@ func (f ActorFlag) ShowInHud() bool {
         return true
     case FlagConcentratedAiming:
         return true
+    case FlagShutDown:
+        return true
+    case FlagEMPDisabled:
+        return true
+    case FlagBleeding:
+        return true
+    case FlagAddicted:
+        return true
+    case FlagWithdrawal:
+        return true
+    case FlagThirst:
+        return true
+    case FlagFatigue:
+        return true
+    case FlagDazzled:
+        return true
     }
     return false
 }
@@ -228,7 +308,7 @@ func (f ActorFlag) ShowInHud() bool {
 const (
     FlagNone ActorFlag = iota
     FlagSleep
-    FlagAwareOfPlayer
+    FlagAwareOfPlayer // Deprecated: replaced by the alert level, kept so the saved flags keep their values
     FlagHunger
     FlagTurnsSinceEating
     FlagStun
@@ -261,6 +341,22 @@ const (
     FlagAnimal
     FlagConcentratedAiming
     FlagTurnsSinceLastIdleChatter
+    FlagGasProtection
+    FlagBegging
+    FlagSurrendered
+    FlagMechanical
+    FlagStationary
+    FlagShutDown
+    FlagEMPDisabled
+    FlagBleeding
+    FlagFirstAidUses
+    FlagAddicted
+    FlagWithdrawal
+    FlagThirst
+    FlagTurnsSinceDrinking
+    FlagFatigue
+    FlagTurnsSinceSleeping
+    FlagDazzled // blinded for a few turns by a critical hit, unlike FlagBlind it always wears off
     FlagCount
 )
 
@@ -329,6 +425,22 @@ func ActorFlagFromString(flag string) ActorFlag {
         return FlagConcentratedAiming
     case "running":
         return FlagRunning
+    case "gas_protection":
+        return FlagGasProtection
+    case "begging":
+        return FlagBegging
+    case "surrendered":
+        return FlagSurrendered
+    case "mechanical":
+        return FlagMechanical
+    case "stationary":
+        return FlagStationary
+    case "shut_down":
+        return FlagShutDown
+    case "emp_disabled":
+        return FlagEMPDisabled
+    case "bleeding":
+        return FlagBleeding
     }
     panic("Invalid actor flag: " + flag)
     return 0
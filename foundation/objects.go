@@ -34,6 +34,14 @@ const (
 	ObjectElevator
 	ObjectPushBox
 	ObjectExplodingPushBox
+	ObjectSwitch
+	ObjectPressurePlate
+	ObjectTripwire
+	ObjectLaserGrid
+	ObjectGasVent
+	ObjectAlarmPanel
+	ObjectBed
+	ObjectWaterSource
 )
 
 func RandomObjectCategory() ObjectCategory {
@@ -49,6 +57,10 @@ func GetAllTrapCategories() []ObjectCategory {
 		ObjectArrowTrap,
 		ObjectDescendTrap,
 		ObjectBearTrap,
+		ObjectPressurePlate,
+		ObjectTripwire,
+		ObjectLaserGrid,
+		ObjectGasVent,
 	}
 }
 
@@ -92,6 +104,22 @@ func (o ObjectCategory) String() string {
 		return "Push Box"
 	case ObjectExplodingPushBox:
 		return "Exploding Push Box"
+	case ObjectSwitch:
+		return "Switch"
+	case ObjectPressurePlate:
+		return "Pressure Plate"
+	case ObjectTripwire:
+		return "Tripwire"
+	case ObjectLaserGrid:
+		return "Laser Grid"
+	case ObjectGasVent:
+		return "Gas Vent"
+	case ObjectAlarmPanel:
+		return "Alarm Panel"
+	case ObjectBed:
+		return "Bed"
+	case ObjectWaterSource:
+		return "Water Source"
 	default:
 		return "Unknown"
 	}
@@ -138,6 +166,22 @@ func ObjectCategoryFromString(s string) ObjectCategory {
 		return ObjectPushBox
 	case "explodingpushbox":
 		return ObjectExplodingPushBox
+	case "switch":
+		return ObjectSwitch
+	case "pressureplate":
+		return ObjectPressurePlate
+	case "tripwire":
+		return ObjectTripwire
+	case "lasergrid":
+		return ObjectLaserGrid
+	case "gasvent":
+		return ObjectGasVent
+	case "alarmpanel":
+		return ObjectAlarmPanel
+	case "bed":
+		return ObjectBed
+	case "watersource":
+		return ObjectWaterSource
 	default:
 		return -1
 	}
@@ -159,13 +203,21 @@ func (o ObjectCategory) ZapEffect() string {
 		return "force_descend_target"
 	case ObjectBearTrap:
 		return "hold_target"
+	case ObjectPressurePlate:
+		return "explode"
+	case ObjectTripwire:
+		return "explode"
+	case ObjectLaserGrid:
+		return "laser_burst"
+	case ObjectGasVent:
+		return "gas_cloud"
 	default:
 		return ""
 	}
 }
 
 func (o ObjectCategory) IsTrap() bool {
-	return o >= ObjectExplodingTrap && o <= ObjectBearTrap
+	return (o >= ObjectExplodingTrap && o <= ObjectBearTrap) || (o >= ObjectPressurePlate && o <= ObjectGasVent)
 }
 
 func (o ObjectCategory) LowerString() string {
@@ -209,6 +261,22 @@ func (o ObjectCategory) LowerString() string {
 		return "pushbox"
 	case ObjectExplodingPushBox:
 		return "explodingpushbox"
+	case ObjectSwitch:
+		return "switch"
+	case ObjectPressurePlate:
+		return "pressureplate"
+	case ObjectTripwire:
+		return "tripwire"
+	case ObjectLaserGrid:
+		return "lasergrid"
+	case ObjectGasVent:
+		return "gasvent"
+	case ObjectAlarmPanel:
+		return "alarmpanel"
+	case ObjectBed:
+		return "bed"
+	case ObjectWaterSource:
+		return "watersource"
 	default:
 		return ""
 	}
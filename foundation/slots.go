@@ -14,6 +14,12 @@ func (n EquipSlot) ToString() string {
 		return "On body"
 	case SlotNameArmorHead:
 		return "On head"
+	case SlotNameOffHand:
+		return "Off hand"
+	case SlotNameBeltOne, SlotNameBeltTwo, SlotNameBeltThree, SlotNameBeltFour:
+		return "On belt"
+	case SlotNameAccessoryOne, SlotNameAccessoryTwo:
+		return "Accessory"
 	}
 	return "Unknown"
 }
@@ -26,6 +32,14 @@ func (n EquipSlot) IsArmorSlot() bool {
 	return false
 }
 
+func (n EquipSlot) IsBeltSlot() bool {
+	return n >= SlotNameBeltOne && n <= SlotNameBeltFour
+}
+
+func (n EquipSlot) IsAccessorySlot() bool {
+	return n == SlotNameAccessoryOne || n == SlotNameAccessoryTwo
+}
+
 const (
 	SlotNameNotEquippable EquipSlot = iota
 
@@ -37,8 +51,23 @@ const (
 
 	// On Body only
 	SlotNameMainHand
+	SlotNameOffHand
+
+	// Belt quick-slots
+	SlotNameBeltOne
+	SlotNameBeltTwo
+	SlotNameBeltThree
+	SlotNameBeltFour
+
+	// Rings, watches, badges
+	SlotNameAccessoryOne
+	SlotNameAccessoryTwo
 )
 
+var BeltSlots = []EquipSlot{SlotNameBeltOne, SlotNameBeltTwo, SlotNameBeltThree, SlotNameBeltFour}
+
+var AccessorySlots = []EquipSlot{SlotNameAccessoryOne, SlotNameAccessoryTwo}
+
 func ItemSlotFromString(s string) EquipSlot {
 	s = strings.ToLower(strings.TrimSpace(s))
 	switch s {
@@ -48,6 +77,12 @@ func ItemSlotFromString(s string) EquipSlot {
 		return SlotNameArmorTorso
 	case "helmet":
 		return SlotNameArmorHead
+	case "off_hand":
+		return SlotNameOffHand
+	case "belt":
+		return SlotNameBeltOne
+	case "accessory":
+		return SlotNameAccessoryOne
 	}
 	panic("Invalid slot: " + s)
 	return SlotNameNotEquippable
@@ -66,6 +101,8 @@ const (
 	TagNoSound
 	TagLightSource
 	TagTimed
+	TagPlaceable
+	TagTripwire
 )
 
 func ItemTagFromString(s string) ItemTags {
@@ -81,6 +118,10 @@ func ItemTagFromString(s string) ItemTags {
 		return TagLightSource
 	case "timed":
 		return TagTimed
+	case "placeable":
+		return TagPlaceable
+	case "tripwire":
+		return TagTripwire
 	}
 	panic("Unknown item tag: " + s)
 	return TagNone
@@ -0,0 +1,286 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"fmt"
+	"github.com/memmaker/go/fxtools"
+	"strconv"
+	"strings"
+)
+
+// Ability is a zap effect an NPC can use in combat, together with the rules for when it makes sense to use it.
+// Abilities are defined in the actor records, eg.
+//
+//	Ability: hold_target range=2-6 cooldown=12 los value=15 duration=8
+//	Ability: teleport_target_away range=1 cooldown=20 hp_below=30 value=25
+//	Ability: haste_target self cooldown=30 ap=2
+//
+// Options that are not known here are passed to the zap effect as parameters.
+type Ability struct {
+	Name     string
+	MinRange int
+	MaxRange int
+	Cooldown int
+	TimeCost int
+	APCost   int
+	NeedsLOS bool
+	OnSelf   bool
+	HPBelow  int
+	Value    int
+	Params   foundation.Params
+	spec     string
+}
+
+// NewAbilityFromString parses an ability definition. An ability without options can be used on adjacent targets.
+func NewAbilityFromString(spec string) (Ability, error) {
+	parts := strings.Fields(spec)
+	if len(parts) == 0 || !zapEffectExists(parts[0]) {
+		return Ability{}, fmt.Errorf("invalid ability: %s", spec)
+	}
+	ability := Ability{
+		Name:     parts[0],
+		MinRange: 0,
+		MaxRange: 1,
+		NeedsLOS: true,
+		Value:    10,
+		Params:   make(foundation.Params),
+		spec:     spec,
+	}
+	for _, option := range parts[1:] {
+		key, value, hasValue := strings.Cut(option, "=")
+		switch strings.ToLower(key) {
+		case "range":
+			if minRange, maxRange, isInterval := strings.Cut(value, "-"); isInterval {
+				ability.MinRange, _ = strconv.Atoi(minRange)
+				ability.MaxRange, _ = strconv.Atoi(maxRange)
+			} else {
+				ability.MaxRange, _ = strconv.Atoi(value)
+			}
+		case "cooldown":
+			ability.Cooldown, _ = strconv.Atoi(value)
+		case "time":
+			ability.TimeCost, _ = strconv.Atoi(value)
+		case "ap":
+			ability.APCost, _ = strconv.Atoi(value)
+		case "los":
+			ability.NeedsLOS = true
+		case "no_los":
+			ability.NeedsLOS = false
+		case "self":
+			ability.OnSelf = true
+		case "hp_below":
+			ability.HPBelow, _ = strconv.Atoi(value)
+		case "value":
+			ability.Value, _ = strconv.Atoi(value)
+		case "damage_interval":
+			ability.Params["damage_interval"] = fxtools.ParseInterval(value)
+		default:
+			if !hasValue {
+				ability.Params[key] = true
+			} else if intValue, err := strconv.Atoi(value); err == nil {
+				ability.Params[key] = intValue
+			} else {
+				ability.Params[key] = value
+			}
+		}
+	}
+	return ability, nil
+}
+
+func (a Ability) String() string {
+	if a.spec != "" {
+		return a.spec
+	}
+	return a.Name
+}
+
+func (a *Actor) AddAbility(ability Ability) {
+	a.abilities = append(a.abilities, ability)
+}
+
+// GetAbilities includes the intrinsic zap effects of the actor, they can be used on adjacent targets.
+func (a *Actor) GetAbilities() []Ability {
+	return a.abilities
+}
+
+// addIntrinsicAbilities turns the intrinsic zap effects into abilities, unless there is a definition with the same name.
+func (a *Actor) addIntrinsicAbilities() error {
+	for _, zapEffect := range a.intrinsicZapEffects {
+		if a.hasAbility(zapEffect) {
+			continue
+		}
+		ability, err := NewAbilityFromString(zapEffect)
+		if err != nil {
+			return err
+		}
+		a.AddAbility(ability)
+	}
+	return nil
+}
+
+func (a *Actor) hasAbility(name string) bool {
+	for _, ability := range a.abilities {
+		if ability.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Actor) getAbilitySpecs() []string {
+	specs := make([]string, len(a.abilities))
+	for i, ability := range a.abilities {
+		specs[i] = ability.String()
+	}
+	return specs
+}
+
+func (a *Actor) setAbilitySpecs(specs []string) error {
+	a.abilities = nil
+	for _, spec := range specs {
+		ability, err := NewAbilityFromString(spec)
+		if err != nil {
+			return err
+		}
+		a.AddAbility(ability)
+	}
+	return a.addIntrinsicAbilities()
+}
+
+// tickAbilityCooldowns is called once per action of the actor.
+func (a *Actor) tickAbilityCooldowns() {
+	for name, turnsLeft := range a.abilityCooldowns {
+		if turnsLeft <= 1 {
+			delete(a.abilityCooldowns, name)
+		} else {
+			a.abilityCooldowns[name] = turnsLeft - 1
+		}
+	}
+}
+
+func (g *GameState) canUseAbility(user *Actor, ability Ability, target *Actor) bool {
+	if user.HasFlag(foundation.FlagCancel) {
+		return false
+	}
+	if _, onCooldown := user.abilityCooldowns[ability.Name]; onCooldown {
+		return false
+	}
+	if ability.APCost > 0 && user.GetCharSheet().GetActionPoints() < ability.APCost {
+		return false
+	}
+	if ability.HPBelow > 0 && user.GetHitPoints()*100 >= user.GetHitPointsMax()*ability.HPBelow {
+		return false
+	}
+	if ability.OnSelf {
+		return true
+	}
+	distance := g.currentMap().MoveDistance(user.Position(), target.Position())
+	if distance < ability.MinRange || distance > ability.MaxRange {
+		return false
+	}
+	if ability.NeedsLOS && !g.canActorSee(user, target.Position()) {
+		return false
+	}
+	return true
+}
+
+// abilityValue estimates how useful the ability is right now.
+// Effects that would do nothing, like holding a target that is already held, are worthless.
+func (g *GameState) abilityValue(user *Actor, ability Ability, target *Actor) int {
+	affected := target
+	if ability.OnSelf {
+		affected = user
+	}
+	switch ability.Name {
+	case "hold_target":
+		if affected.HasFlag(foundation.FlagHeld) {
+			return 0
+		}
+	case "slow_target":
+		if affected.HasFlag(foundation.FlagSlow) {
+			return 0
+		}
+	case "haste_target":
+		if affected.HasFlag(foundation.FlagHaste) {
+			return 0
+		}
+	case "invisibility_target":
+		if affected.HasFlag(foundation.FlagInvisible) {
+			return 0
+		}
+	case "cancel_target":
+		if affected.HasFlag(foundation.FlagCancel) {
+			return 0
+		}
+	}
+	value := ability.Value
+	if ability.Params.Has("damage_interval") {
+		interval := ability.Params.GetInterval("damage_interval")
+		value += (interval.Min + interval.Max) / 2
+	} else if ability.Params.Has("damage") {
+		value += ability.Params.GetInt("damage")
+	}
+	return value
+}
+
+// expectedAttackValue is the average damage of a regular attack against the target, weighted by the chance to hit.
+func (g *GameState) expectedAttackValue(attacker *Actor, target *Actor) int {
+	distance := g.currentMap().MoveDistance(attacker.Position(), target.Position())
+	if weapon, hasRangedWeapon := attacker.GetEquipment().GetRangedWeapon(); hasRangedWeapon && distance < weapon.GetCurrentAttackMode().MaxRange {
+		damage := weapon.GetWeaponDamage()
+		return (damage.Min + damage.Max) / 2 * g.getRangedChanceToHit(attacker, weapon, target) / 100
+	}
+	if distance > 1 {
+		return 0
+	}
+	averageDamage := 4 + attacker.GetMeleeDamageBonus()
+	meleeWeapon, hasMeleeWeapon := attacker.GetEquipment().GetMeleeWeapon()
+	if hasMeleeWeapon && meleeWeapon.IsMeleeWeapon() {
+		damage := meleeWeapon.GetWeaponDamage()
+		averageDamage = attacker.GetMeleeDamageBonus() + (damage.Min+damage.Max)/2
+	}
+	return averageDamage * g.getMeleeChanceToHit(attacker, meleeWeapon, target) / 100
+}
+
+// tryUseAbility picks the most valuable ability that can be used right now.
+// The ability is only used if it's worth more than a regular attack.
+func (g *GameState) tryUseAbility(user *Actor, target *Actor) (int, bool) {
+	var bestAbility Ability
+	bestValue := g.expectedAttackValue(user, target)
+	found := false
+	for _, ability := range user.GetAbilities() {
+		if !g.canUseAbility(user, ability, target) {
+			continue
+		}
+		if value := g.abilityValue(user, ability, target); value > bestValue {
+			bestAbility = ability
+			bestValue = value
+			found = true
+		}
+	}
+	if !found {
+		return 0, false
+	}
+	return g.actorUseAbility(user, bestAbility, target), true
+}
+
+func (g *GameState) actorUseAbility(user *Actor, ability Ability, target *Actor) int {
+	targetPos := target.Position()
+	if ability.OnSelf {
+		targetPos = user.Position()
+	}
+	if ability.Cooldown > 0 {
+		user.abilityCooldowns[ability.Name] = ability.Cooldown
+	}
+	if ability.APCost > 0 {
+		user.GetCharSheet().LooseActionPoints(ability.APCost)
+	}
+	if !ability.OnSelf {
+		user.SetFacing(directionTowards(user.Position(), targetPos))
+	}
+	g.ui.AddAnimations(g.actorInvokeZapEffect(user, ability.Name, targetPos, ability.Params))
+	if ability.TimeCost > 0 {
+		return ability.TimeCost
+	}
+	return user.timeNeededForActions()
+}
@@ -0,0 +1,85 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNewAbilityFromString(t *testing.T) {
+	tests := []struct {
+		spec         string
+		wantName     string
+		wantMinRange int
+		wantMaxRange int
+		wantCooldown int
+		wantAPCost   int
+		wantNeedsLOS bool
+		wantOnSelf   bool
+		wantHPBelow  int
+		wantValue    int
+	}{
+		{"hold_target", "hold_target", 0, 1, 0, 0, true, false, 0, 10},
+		{"hold_target range=2-6 cooldown=12 los value=15", "hold_target", 2, 6, 12, 0, true, false, 0, 15},
+		{"teleport_target_away range=1 cooldown=20 hp_below=30 value=25", "teleport_target_away", 0, 1, 20, 0, true, false, 30, 25},
+		{"haste_target self cooldown=30 ap=2", "haste_target", 0, 1, 30, 2, true, true, 0, 10},
+		{"fire_breath range=4 no_los", "fire_breath", 0, 4, 0, 0, false, false, 0, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			ability, err := NewAbilityFromString(tt.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ability.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", ability.Name, tt.wantName)
+			}
+			if ability.MinRange != tt.wantMinRange || ability.MaxRange != tt.wantMaxRange {
+				t.Errorf("Range = %d-%d, want %d-%d", ability.MinRange, ability.MaxRange, tt.wantMinRange, tt.wantMaxRange)
+			}
+			if ability.Cooldown != tt.wantCooldown {
+				t.Errorf("Cooldown = %d, want %d", ability.Cooldown, tt.wantCooldown)
+			}
+			if ability.APCost != tt.wantAPCost {
+				t.Errorf("APCost = %d, want %d", ability.APCost, tt.wantAPCost)
+			}
+			if ability.NeedsLOS != tt.wantNeedsLOS {
+				t.Errorf("NeedsLOS = %v, want %v", ability.NeedsLOS, tt.wantNeedsLOS)
+			}
+			if ability.OnSelf != tt.wantOnSelf {
+				t.Errorf("OnSelf = %v, want %v", ability.OnSelf, tt.wantOnSelf)
+			}
+			if ability.HPBelow != tt.wantHPBelow {
+				t.Errorf("HPBelow = %d, want %d", ability.HPBelow, tt.wantHPBelow)
+			}
+			if ability.Value != tt.wantValue {
+				t.Errorf("Value = %d, want %d", ability.Value, tt.wantValue)
+			}
+			if ability.String() != tt.spec {
+				t.Errorf("String() = %q, want %q", ability.String(), tt.spec)
+			}
+		})
+	}
+}
+
+func TestNewAbilityFromStringPassesUnknownOptionsAsParams(t *testing.T) {
+	ability, err := NewAbilityFromString("hold_target duration=8 silent label=web")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ability.Params["duration"] != 8 {
+		t.Errorf("duration = %v, want 8", ability.Params["duration"])
+	}
+	if ability.Params["silent"] != true {
+		t.Errorf("silent = %v, want true", ability.Params["silent"])
+	}
+	if ability.Params["label"] != "web" {
+		t.Errorf("label = %v, want web", ability.Params["label"])
+	}
+}
+
+func TestNewAbilityFromStringRejectsUnknownEffects(t *testing.T) {
+	for _, spec := range []string{"", "   ", "summon_dragon range=3"} {
+		if _, err := NewAbilityFromString(spec); err == nil {
+			t.Errorf("NewAbilityFromString(%q) should fail", spec)
+		}
+	}
+}
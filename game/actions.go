@@ -2,6 +2,7 @@ package game
 
 import (
 	"RogueUI/foundation"
+	"RogueUI/special"
 	"fmt"
 	"github.com/memmaker/go/fxtools"
 	"github.com/memmaker/go/geometry"
@@ -83,9 +84,27 @@ func (g *GameState) actorReloadMainHandWeapon(actor *Actor) bool {
 
 // ADDITIONAL MENUS
 
+// PlayerApplySkill lets the player treat their own wounds and work on their implants.
 func (g *GameState) PlayerApplySkill() {
-	//TODO implement me
-	panic("implement me")
+	menuItems := []foundation.MenuItem{
+		{
+			Name:       fmt.Sprintf("First Aid (%d/%d left today)", g.firstAidUsesLeft(), firstAidUsesPerDay),
+			Action:     g.playerFirstAid,
+			CloseMenus: true,
+		},
+	}
+	for _, part := range g.Player.getCrippledBodyParts() {
+		crippledPart := part
+		menuItems = append(menuItems, foundation.MenuItem{
+			Name: fmt.Sprintf("Doctor (%s)", crippledPart.String()),
+			Action: func() {
+				g.playerDoctor(crippledPart)
+			},
+			CloseMenus: true,
+		})
+	}
+	menuItems = g.appendImplantMenuItems(menuItems)
+	g.ui.OpenMenu(menuItems)
 }
 
 func (g *GameState) OpenTacticsMenu() {
@@ -196,7 +215,9 @@ func (g *GameState) playerUseOrZapItem(item foundation.Item) {
 	} else if item.IsUsable() {
 		g.actorUseItem(g.Player, item)
 	} else if item.IsZappable() {
-		if item.HasTag(foundation.TagTimed) {
+		if item.HasTag(foundation.TagPlaceable) || item.HasTag(foundation.TagTripwire) {
+			g.startPlaceTrapItem(item)
+		} else if item.HasTag(foundation.TagTimed) {
 			g.actorSetItemCountdown(g.Player, item)
 		} else {
 			g.startZapItem(item)
@@ -257,6 +278,7 @@ func (g *GameState) playerRepairItemWith(toRepair foundation.Repairable, sparePa
 	toRepair.SetQuality(newQuality)
 
 	g.msg(foundation.HiLite("You repaired %s to %s", toRepair.Name(), fmt.Sprintf("%d%%", newQuality)))
+	g.learnByDoing(special.Mechanics, learnGainTask, int(firstQuality)-100)
 
 	g.ui.UpdateInventory()
 }
@@ -431,7 +453,9 @@ func (g *GameState) EquipToggle(item foundation.Item) {
 			g.msg(foundation.Msg("You cannot remove this item"))
 		}
 	} else {
-		if equipment.CanEquip(item) {
+		if weapon, isWeapon := item.(*Weapon); isWeapon && weapon.IsTwoHanded() && g.Player.IsCrippled(special.Arms) {
+			g.msg(foundation.HiLite("You can't hold %s with a crippled arm", item.Name()))
+		} else if equipment.CanEquip(item) {
 			g.actorEquipItem(g.Player, item)
 		} else {
 			g.msg(foundation.Msg("You cannot equip this item"))
@@ -439,6 +463,92 @@ func (g *GameState) EquipToggle(item foundation.Item) {
 	}
 }
 
+// EquipToOffHand is for shields, light sources and a second one-handed weapon.
+func (g *GameState) EquipToOffHand(item foundation.Item) {
+	equipment := g.Player.GetEquipment()
+	if !canBeHeldInOffHand(item) {
+		g.msg(foundation.HiLite("You can't hold %s in your off hand", item.Name()))
+		return
+	}
+	if !equipment.CanEquipToSlot(item, foundation.SlotNameOffHand) {
+		g.msg(foundation.Msg("You cannot equip this item"))
+		return
+	}
+	equipment.EquipToSlot(item, foundation.SlotNameOffHand)
+	g.msg(foundation.HiLite("You hold %s in your off hand", item.Name()))
+	g.endPlayerTurn(g.Player.timeNeededForActions() / 2)
+}
+
+func (g *GameState) PlayerSwapHands() {
+	if !g.Player.GetEquipment().SwapHands() {
+		g.msg(foundation.Msg("You have nothing to swap"))
+		return
+	}
+	if item, hasItem := g.Player.GetEquipment().GetMainHandItem(); hasItem {
+		g.msg(foundation.HiLite("You are now wielding %s", item.Name()))
+	}
+	g.endPlayerTurn(g.Player.timeNeededForActions() / 2)
+}
+
+// BeltToggle puts an item on the belt quick-slot with the given index or takes it off again.
+func (g *GameState) BeltToggle(item foundation.Item, index int) {
+	equipment := g.Player.GetEquipment()
+	if index < 0 || index >= len(foundation.BeltSlots) {
+		return
+	}
+	slot := foundation.BeltSlots[index]
+	if currentSlot, isEquipped := equipment.GetSlotOf(item); isEquipped && currentSlot == slot {
+		equipment.UnEquip(item)
+		g.msg(foundation.HiLite("You take %s off your belt", item.Name()))
+		return
+	}
+	if !item.CanBePutOnBelt() {
+		g.msg(foundation.HiLite("You can't put %s on your belt", item.Name()))
+		return
+	}
+	if !equipment.CanEquipToSlot(item, slot) {
+		g.msg(foundation.Msg("You cannot equip this item"))
+		return
+	}
+	equipment.EquipToSlot(item, slot)
+	g.msg(foundation.HiLite("You put %s on your belt (%s)", item.Name(), fmt.Sprint(index+1)))
+}
+
+func (g *GameState) GetBeltIndex(item foundation.Item) (int, bool) {
+	slot, isEquipped := g.Player.GetEquipment().GetSlotOf(item)
+	if !isEquipped || !slot.IsBeltSlot() {
+		return 0, false
+	}
+	return int(slot - foundation.SlotNameBeltOne), true
+}
+
+// PlayerUseBeltItem uses the item in a belt quick-slot without opening the inventory.
+// Lockpicks are used on an adjacent locked door.
+func (g *GameState) PlayerUseBeltItem(index int) {
+	equipped, hasItem := g.Player.GetEquipment().GetBeltItem(index)
+	if !hasItem {
+		g.msg(foundation.HiLite("Your belt slot %s is empty", fmt.Sprint(index+1)))
+		return
+	}
+	item := equipped.(foundation.Item)
+	if item.IsLockpick() {
+		g.playerPickAdjacentLock()
+		return
+	}
+	g.playerUseOrZapItem(item)
+}
+
+func (g *GameState) playerPickAdjacentLock() {
+	currentMap := g.currentMap()
+	for _, neighbor := range currentMap.NeighborsAll(g.Player.Position(), currentMap.Contains) {
+		if currentMap.IsObjectAt(neighbor) && currentMap.ObjectAt(neighbor).GetCategory() == foundation.ObjectLockedDoor {
+			currentMap.ObjectAt(neighbor).OnBump(g.Player)
+			return
+		}
+	}
+	g.msg(foundation.Msg("There is no lock to pick nearby"))
+}
+
 func (g *GameState) actorEquipItem(wearer *Actor, item foundation.Item) {
 	equipment := wearer.GetEquipment()
 	equipment.Equip(item)
@@ -143,8 +143,7 @@ func (g *GameState) playerBackstab(defender *Actor) {
 	attackerLuckChance := special.Percentage(g.Player.GetCharSheet().GetDerivedStat(special.CriticalChance))
 	defenderLuckChance := special.Percentage(defender.GetCharSheet().GetDerivedStat(special.CriticalChance))
 
-	attackerStealth := special.Percentage(g.Player.GetCharSheet().GetSkill(special.Stealth))
-	defenderAwareness := special.Percentage(defender.GetCharSheet().GetStat(special.Perception) * 10)
+	attackerStealth, defenderAwareness := g.backstabChances(defender)
 
 	contestResult := special.SkillContest(attackerStealth, attackerLuckChance, defenderAwareness, defenderLuckChance)
 
@@ -160,6 +159,9 @@ func (g *GameState) playerBackstab(defender *Actor) {
 		}
 		g.msg(foundation.HiLite("You stab %s in the back", defender.Name()))
 		g.ui.AddAnimations(g.damageActor(sourcedDamage, defender))
+		if !defender.IsAlive() {
+			g.learnByDoing(special.Stealth, learnGainTask, int(attackerStealth)-int(defenderAwareness))
+		}
 		g.endPlayerTurn(g.Player.timeNeededForMeleeAttack())
 	} else {
 		g.msg(foundation.HiLite("You fail to sneak up on %s", defender.Name()))
@@ -208,7 +210,7 @@ func (g *GameState) playerMeleeAttack(defender *Actor) {
 	doMeleeAttack := func(part special.BodyPart) {
 		consequences := g.actorMeleeAttack(g.Player, defender, part)
 		if !g.Player.HasFlag(foundation.FlagInvisible) {
-			defender.GetFlags().Set(foundation.FlagAwareOfPlayer)
+			defender.ChangeAlertLevel(alertLevelMax)
 		}
 		g.ui.AddAnimations(consequences)
 		g.endPlayerTurn(g.Player.timeNeededForMeleeAttack())
@@ -287,8 +289,8 @@ func (g *GameState) getMeleeDamage(attacker *Actor, cth int, part special.BodyPa
 		attackAudioCue = weapon.GetFireAudioCue(special.TargetingModeFireSingle)
 	}
 
-	isHit := rand.Intn(100) < cth
-	if !isHit {
+	hitRoll := special.SuccessRoll(special.Percentage(cth), criticalChance(attacker, part))
+	if !hitRoll.Success {
 		damage = 0
 	}
 
@@ -300,6 +302,7 @@ func (g *GameState) getMeleeDamage(attacker *Actor, cth int, part special.BodyPa
 		DamageType:      damageType,
 		DamageAmount:    damage,
 		BodyPart:        part,
+		IsCritical:      hitRoll.IsCriticalSuccess(),
 	}
 	return attackAudioCue, damageWithSource
 }
@@ -311,6 +314,7 @@ func (g *GameState) actorRangedAttack(attacker *Actor, weaponItem *Weapon, attac
 	}
 
 	bulletsSpent, weapon := g.removeBulletsFromWeapon(weaponItem, attackMode)
+	g.makeNoise(attacker.Position(), noiseRadiusGunshot, attacker)
 
 	attackAnimations, isProjectileAnimation := g.getWeaponAttackAnim(attacker, defender.Position(), weaponItem, attackMode, bulletsSpent)
 
@@ -344,6 +348,7 @@ func (g *GameState) actorRangedAttack(attacker *Actor, weaponItem *Weapon, attac
 func (g *GameState) actorRangedAttackLocation(attacker *Actor, weaponItem *Weapon, attackMode AttackMode, targetPos geometry.Point) []foundation.Animation {
 
 	bulletsSpent, weapon := g.removeBulletsFromWeapon(weaponItem, attackMode)
+	g.makeNoise(attacker.Position(), noiseRadiusGunshot, attacker)
 
 	onAttackAnims, isProjectileAnimation := g.getWeaponAttackAnim(attacker, targetPos, weaponItem, attackMode, bulletsSpent)
 
@@ -378,11 +383,14 @@ func (g *GameState) calculateRangedDamage(attacker *Actor, weaponItem *Weapon, a
 	damage := weaponItem.GetWeaponDamage()
 	totalDamage := 0
 	damagePerBullet := make([]int, bulletsSpent)
+	isCritical := false
 	for i := 0; i < bulletsSpent; i++ {
 		damageDone := damage.Roll()
-		if rand.Intn(100)+1 >= chanceToHit {
+		hitRoll := special.SuccessRoll(special.Percentage(chanceToHit), criticalChance(attacker, bodyPart))
+		if !hitRoll.Success {
 			damageDone = 0
 		}
+		isCritical = isCritical || hitRoll.IsCriticalSuccess()
 		damagePerBullet[i] = damageDone
 		totalDamage += damageDone
 	}
@@ -413,6 +421,7 @@ func (g *GameState) calculateRangedDamage(attacker *Actor, weaponItem *Weapon, a
 		DamageAmount:    int(float64(totalDamage)*damageFactor) + bonusDamage,
 		BodyPart:        bodyPart,
 		DamagePerBullet: damagePerBullet,
+		IsCritical:      isCritical,
 	}
 	return damageWithSource
 }
@@ -449,7 +458,22 @@ func (g *GameState) applyDamageToActorAnimated(attacker *Actor, weaponItem *Weap
 		dtMod = weapon.GetTargetDTModifier()
 	}
 
-	damageWithSource = defender.ModifyDamageByArmor(damageWithSource, dtMod)
+	isZapWeapon := weaponItem != nil && weaponItem.IsZappable()
+	var crit special.CriticalHit
+	isCritical := damageWithSource.IsCritical && damageWithSource.DamageAmount > 0 && !isZapWeapon && defender.IsAlive()
+	if isCritical {
+		crit = g.criticalHitFor(defender, damageWithSource.BodyPart)
+	}
+	if isCritical {
+		damageWithSource = damageWithSource.Multiplied(crit.DamagePercent)
+	}
+	if !isCritical || !crit.Effects.Has(special.CritBypassArmor) {
+		damageWithSource = defender.ModifyDamageByArmor(damageWithSource, dtMod)
+	}
+	if isCritical && crit.Effects.Has(special.CritInstantDeath) {
+		damageWithSource.DamageAmount = max(damageWithSource.DamageAmount, defender.GetHitPoints())
+		damageWithSource.DamagePerBullet = nil
+	}
 
 	attackedFlag := fmt.Sprintf("WasAttacked(%s)", defender.GetInternalName())
 	g.gameFlags.SetFlag(attackedFlag)
@@ -460,15 +484,28 @@ func (g *GameState) applyDamageToActorAnimated(attacker *Actor, weaponItem *Weap
 	}
 
 	if damageWithSource.DamageAmount > 0 {
-		if weaponItem != nil && weaponItem.IsZappable() {
+		if attacker == g.Player {
+			g.learnByDoing(attackSkill(weaponItem), learnGainCombat, damageWithSource.BodyPart.AimPenalty())
+		}
+		if isZapWeapon {
 			weaponZapEffect := ZapEffectFromName(weaponItem.ZapEffect())
 			damageAnims = weaponZapEffect(g, attacker, defender.Position(), weaponItem.GetEffectParameters())
 		} else {
+			if isCritical {
+				g.criticalHitMessage(defender, crit)
+			}
+			hitPart := defender.mainBodyPart(damageWithSource.BodyPart)
+			wasCrippled := defender.IsCrippled(hitPart)
 			damageAnims = g.damageActor(damageWithSource, defender)
+			if isCritical {
+				g.applyCriticalEffects(defender, damageWithSource.BodyPart, crit)
+			}
+			g.tryStartBleeding(defender, damageWithSource, !wasCrippled && defender.IsCrippled(hitPart))
 		}
 	} else {
 		if damageWithSource.IsObviousAttack {
 			g.trySetHostile(defender, damageWithSource.Attacker)
+			g.alertAllies(defender, damageWithSource.Attacker)
 		}
 		var playMissSound func() = nil
 		if weaponItem != nil && weaponItem.IsWeapon() {
@@ -563,6 +600,7 @@ func (g *GameState) actorThrowItem(thrower *Actor, missile foundation.Item, orig
 		BodyPart:        special.Body,
 	}
 	onHitAnimations = append(onHitAnimations, g.damageLocation(damage, targetPos)...)
+	g.makeNoise(targetPos, noiseRadiusImpact, thrower)
 	// explosion/fragmentation
 	// fire
 	// emp
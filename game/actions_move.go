@@ -17,6 +17,9 @@ func (g *GameState) playerMove(oldPos geometry.Point, newPos geometry.Point) {
 	g.endPlayerTurn(g.Player.timeNeededForMovement())
 }
 func (g *GameState) actorMoveAnimated(actor *Actor, newPos geometry.Point) []foundation.Animation {
+	if actor.IsStationary() {
+		return nil
+	}
 	oldPos := actor.Position()
 	var moveAnims []foundation.Animation
 	if g.couldPlayerSeeActor(actor) && (g.canPlayerSee(newPos) || g.canPlayerSee(oldPos)) && actor != g.Player {
@@ -31,11 +34,16 @@ func (g *GameState) actorMoveAnimated(actor *Actor, newPos geometry.Point) []fou
 }
 func (g *GameState) actorMove(actor *Actor, newPos geometry.Point) []foundation.Animation {
 	oldPos := actor.Position()
-	if oldPos == newPos {
+	if oldPos == newPos || actor.IsStationary() {
 		return nil
 	}
 	g.currentMap().MoveActor(actor, newPos)
 	if actor.Position() == newPos {
+		actor.SetFacing(directionTowards(oldPos, newPos))
+		if actor != g.Player {
+			g.playDoorSfxForNPC(oldPos, newPos)
+		}
+		g.checkTriggersAfterMovement(actor, oldPos, newPos)
 		return g.triggerTileEffectsAfterMovement(actor, oldPos, newPos)
 	}
 	return nil
@@ -12,6 +12,7 @@ import (
 	"github.com/memmaker/go/textiles"
 	"image/color"
 	"math/rand"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -63,32 +64,39 @@ type Actor struct {
 	currentPathBlockedCount int
 	currentPath             []geometry.Point
 	currentPathIndex        int
-	bodyAugmentations       map[CyberWare]bool
 	temporaryStatChanges    []*TemporaryStatChange
-}
 
-func (a *Actor) AddCyberWare(ware CyberWare) {
-	a.bodyAugmentations[ware] = false
-}
+	behaviourName string
+	blackboard    map[string]string
 
-func (a *Actor) RemoveCyberWare(ware CyberWare) {
-	delete(a.bodyAugmentations, ware)
-}
+	facing          geometry.CompassDirection
+	patrolRoute     string
+	patrolIndex     int
+	patrolDirection int
+	patrolWait      int
+	leashRadius     int
 
-func (a *Actor) HasCyberWare(ware CyberWare) bool {
-	_, hasAug := a.bodyAugmentations[ware]
-	return hasAug
-}
+	morale    int
+	maxMorale int
 
-func (a *Actor) IsCyberWareActive(ware CyberWare) bool {
-	if active, hasAug := a.bodyAugmentations[ware]; hasAug {
-		return active
-	}
-	return false
-}
+	squadName string
+
+	alertLevel          int
+	investigatePosition geometry.Point
+	isInvestigating     bool
+	searchTurns         int
+	searchWaypoint      geometry.Point
+
+	abilities        []Ability
+	abilityCooldowns map[string]int
 
-func (a *Actor) SetCyberWareActive(ware CyberWare, active bool) {
-	a.bodyAugmentations[ware] = active
+	alarmLink       string
+	alarmLinkActive bool
+
+	addictions []*Addiction
+
+	implants      map[ImplantSlot]*InstalledImplant
+	implantEnergy int
 }
 
 func (a *Actor) GetState() foundation.AIState {
@@ -205,6 +213,127 @@ func (a *Actor) GobEncode() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = encoder.Encode(a.SpawnPosition)
+	if err != nil {
+		return nil, err
+	}
+
+	savedGoal := NoGoal
+	if a.activeGoal.IsRestorable() {
+		savedGoal = ActorGoal{Kind: a.activeGoal.Kind, Target: a.activeGoal.Target, Location: a.activeGoal.Location, IsReaction: a.activeGoal.IsReaction}
+	}
+	err = encoder.Encode(savedGoal.Kind)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(savedGoal.Target)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(savedGoal.Location)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.behaviourName)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.blackboard)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.facing)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.patrolRoute)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.patrolIndex)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.patrolDirection)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.patrolWait)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.leashRadius)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.morale)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.maxMorale)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.squadName)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.alertLevel)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.investigatePosition)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.isInvestigating)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.searchTurns)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.getAbilitySpecs())
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.abilityCooldowns)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.alarmLink)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.alarmLinkActive)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.temporaryStatChanges)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.addictions)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.implants)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.implantEnergy)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(a.searchWaypoint)
+	if err != nil {
+		return nil, err
+	}
+	err = encoder.Encode(savedGoal.IsReaction)
+	if err != nil {
+		return nil, err
+	}
 
 	return buf.Bytes(), nil
 }
@@ -300,6 +429,139 @@ func (a *Actor) GobDecode(data []byte) error {
 	if err != nil {
 		return err
 	}
+	err = decoder.Decode(&a.SpawnPosition)
+	if err != nil {
+		return err
+	}
+
+	// the closures of the goal are rebuilt by GameState.restoreGoal after loading
+	err = decoder.Decode(&a.activeGoal.Kind)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.activeGoal.Target)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.activeGoal.Location)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.behaviourName)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.blackboard)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.facing)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.patrolRoute)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.patrolIndex)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.patrolDirection)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.patrolWait)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.leashRadius)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.morale)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.maxMorale)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.squadName)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.alertLevel)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.investigatePosition)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.isInvestigating)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.searchTurns)
+	if err != nil {
+		return err
+	}
+	var abilitySpecs []string
+	err = decoder.Decode(&abilitySpecs)
+	if err != nil {
+		return err
+	}
+	err = a.setAbilitySpecs(abilitySpecs)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.abilityCooldowns)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.alarmLink)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.alarmLinkActive)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.temporaryStatChanges)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.addictions)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.implants)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.implantEnergy)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.searchWaypoint)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&a.activeGoal.IsReaction)
+	if err != nil {
+		return err
+	}
+	if a.implants == nil {
+		a.implants = make(map[ImplantSlot]*InstalledImplant)
+	}
+	if a.abilityCooldowns == nil {
+		a.abilityCooldowns = make(map[string]int)
+	}
+	if a.blackboard == nil {
+		a.blackboard = make(map[string]string)
+	}
+	a.charSheet.SetStatModifierHandler(a.GetInjuryStatModifiers)
 
 	return nil
 }
@@ -326,19 +588,24 @@ func NewActor() *Actor {
 			Fg:   color.RGBA{255, 255, 255, 255},
 			Bg:   color.RGBA{0, 0, 0, 255},
 		},
-		equipment:         NewEquipment(),
-		charSheet:         sheet,
-		bodyAugmentations: make(map[CyberWare]bool),
-		body:              special.HumanBodyParts,
-		bodyDamage:        make(map[special.BodyPart]int),
-		aiState:           foundation.Neutral,
-		statusFlags:       foundation.NewActorFlags(),
-		enemyActors:       make(map[string]bool),
-		enemyTeams:        make(map[string]bool),
-		activeGoal:        NoGoal,
-		audioBaseName:     "human_male",
+		equipment:        NewEquipment(),
+		charSheet:        sheet,
+		implants:         make(map[ImplantSlot]*InstalledImplant),
+		body:             special.HumanBodyParts,
+		bodyDamage:       make(map[special.BodyPart]int),
+		aiState:          foundation.Neutral,
+		statusFlags:      foundation.NewActorFlags(),
+		enemyActors:      make(map[string]bool),
+		enemyTeams:       make(map[string]bool),
+		abilityCooldowns: make(map[string]int),
+		activeGoal:       NoGoal,
+		blackboard:       make(map[string]string),
+		facing:           geometry.South,
+		patrolDirection:  1,
+		audioBaseName:    "human_male",
 	}
 	a.inventory = NewInventory(23, a.Position)
+	a.charSheet.SetStatModifierHandler(a.GetInjuryStatModifiers)
 	return a
 }
 
@@ -541,6 +808,7 @@ func (a *Actor) TakeDamage(dmg SourcedDamage) (didCripple bool) {
 }
 
 func (a *Actor) addDamageToBodyPart(dmg SourcedDamage) (didCripple bool) {
+	dmg.BodyPart = a.mainBodyPart(dmg.BodyPart)
 	wasCrippled := a.IsCrippled(dmg.BodyPart)
 	a.bodyDamage[dmg.BodyPart] += dmg.DamageAmount
 	return !wasCrippled && a.IsCrippled(dmg.BodyPart)
@@ -664,6 +932,24 @@ func (a *Actor) GetDetailInfo() string {
 	result = append(result, resistanceLines...)
 	result = append(result, "", "> Skills:")
 	result = append(result, skillLines...)
+	if traits := a.charSheet.GetTraits(); len(traits) > 0 {
+		result = append(result, "", "> Traits:")
+		for _, trait := range traits {
+			result = append(result, trait.Name)
+		}
+	}
+	if len(a.addictions) > 0 {
+		result = append(result, "", "> Addictions:")
+		for _, addiction := range a.addictions {
+			result = append(result, addiction.Drug)
+		}
+	}
+	if len(a.implants) > 0 {
+		result = append(result, "", "> Implants:")
+		for _, implant := range a.GetInstalledImplants() {
+			result = append(result, fmt.Sprintf("%s (%s)", implant.Description, implant.Slot.String()))
+		}
+	}
 	return strings.Join(result, "\n")
 }
 
@@ -714,18 +1000,19 @@ func (a *Actor) Satiate() {
 func (a *Actor) SetSleeping() {
 	flags := a.GetFlags()
 	flags.Set(foundation.FlagSleep)
-	flags.Unset(foundation.FlagAwareOfPlayer)
 	flags.Unset(foundation.FlagScared)
+	a.alertLevel = 0
+	a.StopInvestigating()
 }
 
 func (a *Actor) SetAware() {
 	flags := a.GetFlags()
 	flags.Unset(foundation.FlagSleep)
-	flags.Set(foundation.FlagAwareOfPlayer)
+	a.alertLevel = alertLevelMax
 }
 
 func (a *Actor) IsBlind() bool {
-	return a.HasFlag(foundation.FlagBlind)
+	return a.HasFlag(foundation.FlagBlind) || a.HasFlag(foundation.FlagDazzled)
 }
 
 func (a *Actor) AddTimeEnergy(timeSpent int) {
@@ -754,7 +1041,7 @@ func (a *Actor) timeNeededForMovement() int {
 		speed *= 6
 	}
 
-	if a.IsCrippled(special.Legs) {
+	if a.IsCrippled(special.Legs) || a.IsCrippled(special.Mobility) {
 		speed = max(1, speed/2)
 	}
 	if a.IsOverEncumbered() {
@@ -762,15 +1049,15 @@ func (a *Actor) timeNeededForMovement() int {
 	}
 	speed = max(1, speed-a.GetEncumbrance())
 	timeNeeded := 100 / speed
-	return timeNeeded
+	return max(1, a.charSheet.ApplyTraitRule(special.RuleMovementTime, timeNeeded))
 }
 
 func (a *Actor) timeNeededForActions() int {
 	speed := a.GetBasicSpeed()
-	if a.IsCrippled(special.Arms) {
+	if a.IsCrippled(special.Arms) || a.IsCrippled(special.Weapons) {
 		speed = max(1, speed-2)
 	}
-	if a.IsCrippled(special.Eyes) {
+	if a.IsCrippled(special.Eyes) || a.IsCrippled(special.Sensors) {
 		speed = max(1, speed-1)
 	}
 	speed = max(1, speed-a.GetEncumbrance())
@@ -785,6 +1072,7 @@ func (a *Actor) AfterTurn() {
 	a.GetEquipment().AfterTurn()
 	a.decrementStatusEffectCounters()
 	a.decrementTemporaryStatChanges()
+	a.applyInjuryEffects()
 	if a.HasFlag(foundation.FlagRunning) {
 		sheet := a.GetCharSheet()
 		if sheet.GetActionPoints() > 0 {
@@ -803,6 +1091,9 @@ func (a *Actor) decrementStatusEffectCounters() {
 	flags.Decrement(foundation.FlagFly)
 	flags.Decrement(foundation.FlagSeeInvisible)
 	flags.Decrement(foundation.FlagHallucinating)
+	flags.Decrement(foundation.FlagEMPDisabled)
+	flags.Decrement(foundation.FlagDazzled)
+	flags.Decrement(foundation.FlagKnockedDown)
 }
 
 func (a *Actor) decrementTemporaryStatChanges() {
@@ -811,6 +1102,9 @@ func (a *Actor) decrementTemporaryStatChanges() {
 		statChange.TurnsLeft--
 		if statChange.TurnsLeft <= 0 {
 			a.temporaryStatChanges = append(a.temporaryStatChanges[:i], a.temporaryStatChanges[i+1:]...)
+			if statChange.AfterEffect != nil {
+				a.temporaryStatChanges = append(a.temporaryStatChanges, statChange.AfterEffect)
+			}
 		}
 	}
 }
@@ -842,7 +1136,11 @@ func (a *Actor) SetDisplayName(name string) {
 	a.name = name
 }
 
+// GetDialogueFile falls back to a generic conversation for surrendered actors without one of their own.
 func (a *Actor) GetDialogueFile() string {
+	if a.dialogueFile == "" && a.IsSurrendered() {
+		return "_surrendered"
+	}
 	return a.dialogueFile
 }
 
@@ -943,6 +1241,16 @@ func (a *Actor) GetTeam() string {
 	return a.teamName
 }
 
+// SetTeam moves the actor to another team and drops any grudges against its new team mates.
+func (a *Actor) SetTeam(name string) {
+	a.teamName = name
+	delete(a.enemyTeams, name)
+}
+
+func (a *Actor) RemoveFromEnemyActors(name string) {
+	delete(a.enemyActors, name)
+}
+
 func (a *Actor) AddToEnemyActors(name string) {
 	if a.internalName == name {
 		return
@@ -973,6 +1281,103 @@ func (a *Actor) IsHostileTowards(attacker *Actor) bool {
 	return false
 }
 
+// WantsToAttack checks the AI state and grudges of the actor, regardless of its current goal.
+func (a *Actor) WantsToAttack(target *Actor) bool {
+	switch a.aiState {
+	case foundation.AttackEverything:
+		return true
+	case foundation.AttackEnemies:
+		return a.enemyActors[target.GetInternalName()] || a.enemyTeams[target.GetTeam()]
+	}
+	return false
+}
+
+// IsEnemyOf is true for actors that are fighting the target or want to, with or without an active goal.
+// Use it for everything the player sees or can exploit, eg. the UI, the context menus and the player's mines.
+func (a *Actor) IsEnemyOf(target *Actor) bool {
+	return a.IsHostileTowards(target) || a.WantsToAttack(target)
+}
+
+func (a *Actor) GetFacing() geometry.CompassDirection {
+	return a.facing
+}
+
+func (a *Actor) SetFacing(direction geometry.CompassDirection) {
+	a.facing = direction
+}
+
+// SetPatrolRoute makes the actor walk the named route of its map while it is idle.
+func (a *Actor) SetPatrolRoute(name string) {
+	a.patrolRoute = name
+	a.patrolIndex = 0
+	a.patrolDirection = 1
+	a.patrolWait = 0
+}
+
+func (a *Actor) GetPatrolRoute() string {
+	return a.patrolRoute
+}
+
+// SetLeashRadius sets how far the actor may stray from its post before it walks back.
+// Zero means the actor doesn't return on its own.
+func (a *Actor) SetLeashRadius(radius int) {
+	a.leashRadius = radius
+}
+
+func (a *Actor) SetBehaviour(name string) {
+	a.behaviourName = name
+}
+
+func (a *Actor) GetBehaviour() string {
+	return a.behaviourName
+}
+
+// SetBlackboard stores a value the behaviour tree of the actor remembers between turns.
+// An empty value removes the key.
+func (a *Actor) SetBlackboard(key, value string) {
+	if value == "" {
+		delete(a.blackboard, key)
+		return
+	}
+	a.blackboard[key] = value
+}
+
+func (a *Actor) GetBlackboard(key string) string {
+	return a.blackboard[key]
+}
+
+// GetMaxMorale is either set in the actor's record or derived from Charisma and Endurance.
+func (a *Actor) GetMaxMorale() int {
+	if a.maxMorale > 0 {
+		return a.maxMorale
+	}
+	return 20 + 5*(a.charSheet.GetStat(special.Charisma)+a.charSheet.GetStat(special.Endurance))
+}
+
+func (a *Actor) SetMaxMorale(value int) {
+	a.maxMorale = value
+}
+
+func (a *Actor) GetMorale() int {
+	return a.morale
+}
+
+func (a *Actor) ResetMorale() {
+	a.morale = a.GetMaxMorale()
+}
+
+func (a *Actor) ChangeMorale(delta int) {
+	a.morale = max(0, min(a.GetMaxMorale(), a.morale+delta))
+}
+
+func (a *Actor) IsBegging() bool {
+	return a.HasFlag(foundation.FlagBegging)
+}
+
+func (a *Actor) IsSurrendered() bool {
+	return a.HasFlag(foundation.FlagSurrendered)
+}
+
 func (a *Actor) IsPanicking() bool {
 	return a.aiState == foundation.Panic
 }
@@ -1047,7 +1452,7 @@ func (a *Actor) ModifyDamageByArmor(damage SourcedDamage, dtModifierFromAttack i
 }
 
 func (a *Actor) HasDialogue() bool {
-	return a.dialogueFile != ""
+	return a.dialogueFile != "" || a.IsSurrendered()
 }
 
 func (a *Actor) HasStealableItems() bool {
@@ -1085,6 +1490,7 @@ func (a *Actor) SetIcon(icon textiles.TextIcon) {
 
 func (a *Actor) SetCharSheet(character *special.CharSheet) {
 	a.charSheet = character
+	a.charSheet.SetStatModifierHandler(a.GetInjuryStatModifiers)
 }
 
 func (a *Actor) SetXP(xp int) {
@@ -1102,6 +1508,39 @@ func (a *Actor) ToRecord() recfile.Record {
 		recfile.Field{Name: "Team", Value: a.teamName},
 		recfile.Field{Name: "XP", Value: recfile.IntStr(a.xp)},
 	}, a.charSheet.ToRecord()...)
+	if a.behaviourName != "" {
+		actorRecord = append(actorRecord, recfile.Field{Name: "Behaviour", Value: a.behaviourName})
+	}
+	if a.patrolRoute != "" {
+		actorRecord = append(actorRecord, recfile.Field{Name: "Patrol", Value: a.patrolRoute})
+	}
+	if a.leashRadius > 0 {
+		actorRecord = append(actorRecord, recfile.Field{Name: "Leash", Value: recfile.IntStr(a.leashRadius)})
+	}
+	if a.maxMorale > 0 {
+		actorRecord = append(actorRecord, recfile.Field{Name: "Morale", Value: recfile.IntStr(a.maxMorale)})
+	}
+	if a.squadName != "" {
+		actorRecord = append(actorRecord, recfile.Field{Name: "Squad", Value: a.squadName})
+	}
+	for _, ability := range a.abilities {
+		if ability.String() == ability.Name && slices.Contains(a.intrinsicZapEffects, ability.Name) {
+			// added from the zap effects
+			continue
+		}
+		actorRecord = append(actorRecord, recfile.Field{Name: "Ability", Value: ability.String()})
+	}
+	if len(a.enemyTeams) > 0 {
+		var enemyTeams []string
+		for teamName := range a.enemyTeams {
+			enemyTeams = append(enemyTeams, teamName)
+		}
+		slices.Sort(enemyTeams)
+		actorRecord = append(actorRecord, recfile.Field{Name: "Enemies", Value: strings.Join(enemyTeams, "|")})
+	}
+	if a.alarmLink != "" {
+		actorRecord = append(actorRecord, recfile.Field{Name: "Alarm", Value: a.alarmLink})
+	}
 	return actorRecord
 }
 
@@ -1282,7 +1721,7 @@ func (a *Actor) GetTemporarySkillModifiers(skill special.Skill) []special.Modifi
 		if value, exists := statChange.SkillChanges[skill]; exists {
 			result = append(result, special.DefaultModifier{
 				Source:    statChange.Name,
-				Modifier:  value,
+				Modifier:  statChange.Stacked(value),
 				Order:     1,
 				IsPercent: true,
 				Suffix:    fmt.Sprintf("(%d turns left)", statChange.TurnsLeft),
@@ -1300,7 +1739,7 @@ func (a *Actor) GetTemporarySkillModifiers(skill special.Skill) []special.Modifi
 		})
 	}
 
-	return result
+	return append(result, a.getWithdrawalSkillModifiers(skill)...)
 }
 
 func (a *Actor) GetTemporaryStatModifiers(stat special.Stat) []special.Modifier {
@@ -1309,13 +1748,13 @@ func (a *Actor) GetTemporaryStatModifiers(stat special.Stat) []special.Modifier
 		if value, exists := statChange.StatChanges[stat]; exists {
 			result = append(result, special.DefaultModifier{
 				Source:   statChange.Name,
-				Modifier: value,
+				Modifier: statChange.Stacked(value),
 				Order:    1,
 				Suffix:   fmt.Sprintf("(%d turns left)", statChange.TurnsLeft),
 			})
 		}
 	}
-	return result
+	return append(result, a.getWithdrawalStatModifiers(stat)...)
 }
 
 func (a *Actor) GetTemporaryDerivedStatModifiers(stat special.DerivedStat) []special.Modifier {
@@ -1324,13 +1763,13 @@ func (a *Actor) GetTemporaryDerivedStatModifiers(stat special.DerivedStat) []spe
 		if value, exists := statChange.DerivedStatChanges[stat]; exists {
 			result = append(result, special.DefaultModifier{
 				Source:   statChange.Name,
-				Modifier: value,
+				Modifier: statChange.Stacked(value),
 				Order:    1,
 				Suffix:   fmt.Sprintf("(%d turns left)", statChange.TurnsLeft),
 			})
 		}
 	}
-	return result
+	return append(result, a.getWithdrawalDerivedStatModifiers(stat)...)
 }
 
 func (a *Actor) AddTemporaryStatChange(change *TemporaryStatChange) {
@@ -1351,21 +1790,90 @@ type StatChange struct {
 
 type TemporaryStatChange struct {
 	StatChange
-	Name      string
-	TurnsLeft int
+	Name        string
+	TurnsLeft   int
+	Doses       int
+	AfterEffect *TemporaryStatChange
+}
+
+// Stacked multiplies the value with the number of doses taken.
+func (t *TemporaryStatChange) Stacked(value int) int {
+	return value * max(1, t.Doses)
 }
 
+type GoalKind uint8
+
+const (
+	GoalKindCustom GoalKind = iota
+	GoalKindMoveToSpawn
+	GoalKindMoveIntoShootingRange
+	GoalKindKillActor
+	GoalKindMoveToLocation
+)
+
+// ActorGoal is a pair of closures. Kind, Target and Location describe the goal
+// so it can be saved with the actor and rebuilt with restoreGoal.
+// Custom goals can't be restored and are dropped on save.
 type ActorGoal struct {
+	Kind     GoalKind
+	Target   string
+	Location geometry.Point
 	Action   func(g *GameState, a *Actor) int
 	Achieved func(g *GameState, a *Actor) bool
+	// IsReaction marks goals the AI set itself after being attacked, they are dropped when the target is out of sight.
+	IsReaction bool
 }
 
 func (g ActorGoal) IsEmpty() bool {
 	return g.Action == nil && g.Achieved == nil
 }
 
+func (g ActorGoal) IsRestorable() bool {
+	return !g.IsEmpty() && g.Kind != GoalKindCustom
+}
+
+// restoreGoal rebuilds the closures of a goal that was loaded from a savegame.
+// Target actors are looked up by their internal name on the current map.
+func (g *GameState) restoreGoal(goal ActorGoal) ActorGoal {
+	targetByName := func(attacker *Actor) *Actor {
+		target := g.actorWithName(goal.Target)
+		if target == nil {
+			return attacker
+		}
+		return target
+	}
+	switch goal.Kind {
+	case GoalKindMoveToSpawn:
+		return GoalMoveToSpawn()
+	case GoalKindMoveToLocation:
+		return GoalMoveToLocation(goal.Location)
+	case GoalKindMoveIntoShootingRange:
+		restored := goal
+		restored.Action = func(g *GameState, a *Actor) int {
+			return moveIntoShootingRange(g, a, targetByName(a))
+		}
+		restored.Achieved = func(g *GameState, a *Actor) bool {
+			target := targetByName(a)
+			return target == a || g.IsInShootingRange(a, target)
+		}
+		return restored
+	case GoalKindKillActor:
+		restored := goal
+		restored.Action = func(g *GameState, a *Actor) int {
+			return tryKill(g, a, targetByName(a))
+		}
+		restored.Achieved = func(g *GameState, a *Actor) bool {
+			target := targetByName(a)
+			return target == a || !target.IsAlive() || !a.IsAlive()
+		}
+		return restored
+	}
+	return NoGoal
+}
+
 func GoalMoveToSpawn() ActorGoal {
 	return ActorGoal{
+		Kind: GoalKindMoveToSpawn,
 		Action: func(g *GameState, a *Actor) int {
 			targetPos := a.SpawnPosition
 			return moveTowards(g, a, targetPos)
@@ -1378,6 +1886,8 @@ func GoalMoveToSpawn() ActorGoal {
 
 func GoalMoveIntoShootingRange(target *Actor) ActorGoal {
 	return ActorGoal{
+		Kind:   GoalKindMoveIntoShootingRange,
+		Target: target.GetInternalName(),
 		Action: func(g *GameState, a *Actor) int {
 			return moveIntoShootingRange(g, a, target)
 		},
@@ -1389,6 +1899,8 @@ func GoalMoveIntoShootingRange(target *Actor) ActorGoal {
 
 func GoalKillActor(attacker *Actor, victim *Actor) ActorGoal {
 	return ActorGoal{
+		Kind:   GoalKindKillActor,
+		Target: victim.GetInternalName(),
 		Action: func(g *GameState, a *Actor) int {
 			return tryKill(g, a, victim)
 		},
@@ -1400,6 +1912,8 @@ func GoalKillActor(attacker *Actor, victim *Actor) ActorGoal {
 
 func GoalMoveToLocation(loc geometry.Point) ActorGoal {
 	return ActorGoal{
+		Kind:     GoalKindMoveToLocation,
+		Location: loc,
 		Action: func(g *GameState, a *Actor) int {
 			return moveTowards(g, a, loc)
 		},
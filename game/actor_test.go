@@ -0,0 +1,41 @@
+package game
+
+import (
+	"github.com/memmaker/go/geometry"
+	"testing"
+)
+
+func TestActorIsEnemyOf(t *testing.T) {
+	player := NewActor()
+	player.SetInternalName("player")
+
+	tests := []struct {
+		name  string
+		setup func(actor *Actor)
+		want  bool
+	}{
+		{"neutral", func(actor *Actor) {}, false},
+		{"attacks everything without a goal", func(actor *Actor) { actor.SetHostile() }, true},
+		{"attacks everything with a kill goal", func(actor *Actor) {
+			actor.SetHostile()
+			actor.SetGoal(GoalKillActor(actor, player))
+		}, true},
+		{"grudge without a goal", func(actor *Actor) { actor.SetHostileTowards(player) }, true},
+		{"alarm responder searching for the player", func(actor *Actor) {
+			actor.respondToAlarm(player, geometry.Point{X: 3, Y: 4})
+		}, true},
+		{"calmed down", func(actor *Actor) {
+			actor.SetHostileTowards(player)
+			actor.SetNeutral()
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actor := NewActor()
+			tt.setup(actor)
+			if got := actor.IsEnemyOf(player); got != tt.want {
+				t.Errorf("IsEnemyOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
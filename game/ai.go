@@ -11,6 +11,12 @@ func (g *GameState) TryAIAction(enemy *Actor) int {
 		return 0 // not enough time energy for any action, spend 0 to accumulate
 	}
 	enemy.GetFlags().Increment(foundation.FlagTurnsSinceLastIdleChatter)
+	enemy.tickAbilityCooldowns()
+	g.updateAlarmLink(enemy)
+
+	if enemy.IsDisabled() {
+		return enemy.timeEnergy
+	}
 
 	if enemy.HasFlag(foundation.FlagStun) {
 		stunCounter := enemy.GetFlags().Get(foundation.FlagStun)
@@ -38,6 +44,18 @@ func (g *GameState) TryAIAction(enemy *Actor) int {
 		}
 	}
 
+	if enemy.IsKnockedDown() {
+		return enemy.timeEnergy
+	}
+
+	if enemy.IsSurrendered() {
+		return enemy.timeEnergy
+	}
+
+	if enemy.IsBegging() {
+		return g.actBegging(enemy)
+	}
+
 	distanceToPlayer := geometry.DistanceChebyshev(enemy.Position(), g.Player.Position())
 
 	nearEachOther := distanceToPlayer <= 7
@@ -65,9 +83,14 @@ func (g *GameState) TryAIAction(enemy *Actor) int {
 		}
 	}
 
+	if tuSpent, handled := g.tryRunToAlarm(enemy); handled {
+		return tuSpent
+	}
+
 	if enemy.HasFlag(foundation.FlagScared) {
 		if !nearEachOther && rand.Intn(3) == 0 {
 			enemy.GetFlags().Unset(foundation.FlagScared)
+			enemy.ChangeMorale(enemy.GetMaxMorale() / 4)
 			g.msg(foundation.HiLite("%s regains its courage", enemy.Name()))
 		} else {
 			newPos := g.currentMap().GetMoveOnPlayerDijkstraMap(enemy.Position(), false, g.playerDijkstraMap)
@@ -77,11 +100,30 @@ func (g *GameState) TryAIAction(enemy *Actor) int {
 		}
 	}
 
+	if g.hasLostTrackOfPlayer(enemy) {
+		// search at the last known position instead of following the player around
+		enemy.SetGoal(NoGoal)
+	}
+
 	if enemy.HasActiveGoal() {
 		return enemy.ActOnGoal(g)
 	}
-	inCombat := enemy.IsInCombat()
-	if !inCombat {
+
+	if tuSpent, handled := g.trySquadAction(enemy); handled {
+		return tuSpent
+	}
+
+	if tuSpent, handled := g.tickBehaviour(enemy); handled {
+		return tuSpent
+	}
+
+	target := g.selectTarget(enemy)
+	if target == nil {
+		g.decayAlert(enemy)
+
+		if tuSpent, searching := g.investigate(enemy); searching {
+			return tuSpent
+		}
 
 		// IDLE STUFF HERE
 		if nearEachOther && g.canPlayerSee(enemy.Position()) && enemy.chatterFile != "" && enemy.GetFlags().Get(foundation.FlagTurnsSinceLastIdleChatter) > 40 && rand.Intn(4) == 0 {
@@ -98,21 +140,18 @@ func (g *GameState) TryAIAction(enemy *Actor) int {
 			}
 		}
 
-		return enemy.timeEnergy // just wait and spend all time energy
-	}
+		if tuSpent, moved := g.idleMovement(enemy); moved {
+			return tuSpent
+		}
 
-	losToPlayer := g.canPlayerSee(enemy.Position())
-	if !enemy.HasFlag(foundation.FlagAwareOfPlayer) && nearEachOther && losToPlayer && CanPerceive(enemy, g.Player) {
-		enemy.GetFlags().Set(foundation.FlagAwareOfPlayer)
-		g.msg(foundation.HiLite("%s notices you", enemy.Name()))
+		return enemy.timeEnergy // just wait and spend all time energy
 	}
 
-	if !enemy.HasFlag(foundation.FlagAwareOfPlayer) {
-		return enemy.timeEnergy
-	}
+	g.noticeTarget(enemy, target)
 
-	if !enemy.IsHostileTowards(g.Player) {
-		return enemy.timeEnergy
+	// selectTarget only returns actors this one wants to fight, the player included
+	if target != g.Player {
+		return g.defaultBehaviour(enemy, target)
 	}
 
 	wantToChase := nearEachOther || enemy.HasFlag(foundation.FlagChase)
@@ -125,44 +164,36 @@ func (g *GameState) TryAIAction(enemy *Actor) int {
 	} else if enemy.HasActiveGoal() {
 		return enemy.ActOnGoal(g)
 	} else {
-		return g.defaultBehaviour(enemy)
+		return g.defaultBehaviour(enemy, g.Player)
 	}
 }
 
-func (g *GameState) defaultBehaviour(enemy *Actor) int {
-	distanceToPlayer := g.currentMap().MoveDistance(enemy.Position(), g.Player.Position())
+func (g *GameState) defaultBehaviour(enemy *Actor, target *Actor) int {
+	distanceToTarget := g.currentMap().MoveDistance(enemy.Position(), target.Position())
 
-	sameRoom := distanceToPlayer <= 1
+	sameRoom := distanceToTarget <= 1
+
+	if tuSpent, used := g.tryUseAbility(enemy, target); used {
+		return tuSpent
+	}
 
 	rangedWeapon, hasRangedWeapon := enemy.GetEquipment().GetRangedWeapon()
 	if hasRangedWeapon {
 		attackMode := rangedWeapon.GetCurrentAttackMode()
 		weaponRange := attackMode.MaxRange - 1
-		if distanceToPlayer <= weaponRange && g.canPlayerSee(enemy.Position()) {
-			consequencesOfMonsterRangedAttack := g.actorRangedAttack(enemy, rangedWeapon, attackMode, g.Player, 0)
+		if distanceToTarget <= weaponRange && g.canActorSee(enemy, target.Position()) {
+			consequencesOfMonsterRangedAttack := g.actorRangedAttack(enemy, rangedWeapon, attackMode, target, 0)
 			g.ui.AddAnimations(consequencesOfMonsterRangedAttack)
 			return attackMode.TUCost
 		}
 	}
 
-	if distanceToPlayer <= 1 {
-		consequencesOfMonsterAttack := g.actorMeleeAttack(enemy, g.Player, 0)
+	if distanceToTarget <= 1 {
+		consequencesOfMonsterAttack := g.actorMeleeAttack(enemy, target, 0)
 		g.ui.AddAnimations(consequencesOfMonsterAttack)
 		return enemy.GetMeleeTUCost()
 	}
 
-	// has skills?
-	zaps := enemy.GetIntrinsicZapEffects()
-	canZap := len(zaps) > 0 && !enemy.HasFlag(foundation.FlagCancel)
-	if canZap && sameRoom { //rand.Intn(3) == 0 {
-		// zap
-		zap := zaps[rand.Intn(len(zaps))]
-		targetPos := g.Player.Position()
-		consequencesOfMonsterZap := g.actorInvokeZapEffect(enemy, zap, targetPos, foundation.Params{})
-		g.ui.AddAnimations(consequencesOfMonsterZap)
-		return enemy.timeNeededForActions()
-	}
-
 	aiUseEffects := enemy.GetIntrinsicUseEffects()
 	canUse := len(aiUseEffects) > 0 && !enemy.HasFlag(foundation.FlagCancel)
 	if canUse && sameRoom {
@@ -176,8 +207,10 @@ func (g *GameState) defaultBehaviour(enemy *Actor) int {
 	var newPos geometry.Point
 	if !gridMap.IsTileWalkable(enemy.Position()) {
 		newPos = gridMap.GetRandomFreeAndSafeNeighbor(rand.New(rand.NewSource(23)), enemy.Position())
-	} else {
+	} else if target == g.Player {
 		newPos = gridMap.GetMoveOnPlayerDijkstraMap(enemy.Position(), true, g.playerDijkstraMap)
+	} else {
+		return moveTowards(g, enemy, target.Position())
 	}
 	consequencesOfMonsterMove := g.actorMoveAnimated(enemy, newPos)
 	g.ui.AddAnimations(consequencesOfMonsterMove)
@@ -0,0 +1,341 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/gridmap"
+	"RogueUI/special"
+	"fmt"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"path"
+	"strings"
+)
+
+// alarmPanelSearchRadius is how far frightened NPCs will run to sound the alarm.
+const alarmPanelSearchRadius = 20
+
+// activeAlarm is the state of an alarm that went off.
+// The alarm itself is defined in the objects of the map, the reinforcements in the reinforcements.rec of the map.
+type activeAlarm struct {
+	mapName      string
+	turnsActive  int
+	turnsUnseen  int
+	wavesSpawned int
+	lastKnownPos geometry.Point
+	lockedDoors  []string
+}
+
+func alarmFlagName(name string) string {
+	return fmt.Sprintf("AlarmActive(%s)", name)
+}
+
+func (g *GameState) IsAlarmActive(name string) bool {
+	return g.gameFlags.HasFlag(alarmFlagName(name))
+}
+
+func (g *GameState) getAlarmDefinition(name string) gridmap.Alarm {
+	if alarm, exists := g.currentMap().GetAlarm(name); exists {
+		return alarm
+	}
+	return gridmap.Alarm{Name: name, Timeout: gridmap.DefaultAlarmTimeout}
+}
+
+// isAlarmResponder is true for the NPCs of the alarm's team or squad, they hunt the player while the alarm is active.
+// Alarms without a team only lock the doors and call the reinforcements, everyone else on the map stays out of it.
+func (g *GameState) isAlarmResponder(alarm gridmap.Alarm, actor *Actor) bool {
+	if alarm.Team == "" || actor == g.Player || !actor.IsAlive() || actor.IsSurrendered() || actor.IsAlliedWith(g.Player) || actor.HasFlag(foundation.FlagAnimal) {
+		return false
+	}
+	return actor.GetTeam() == alarm.Team || actor.GetSquad() == alarm.Team
+}
+
+// triggerAlarm raises the alarm of the current map. The source is the actor that set it off, if any.
+// If the alarm is already active, it just updates the last known position of the player.
+func (g *GameState) triggerAlarm(name string, source *Actor, origin geometry.Point) {
+	lastKnownPos := origin
+	if source == g.Player || (source != nil && g.isInVisionOf(source, g.Player.Position())) {
+		lastKnownPos = g.Player.Position()
+	}
+	if state, isActive := g.activeAlarms[name]; isActive {
+		state.turnsUnseen = 0
+		state.lastKnownPos = lastKnownPos
+		return
+	}
+
+	alarm := g.getAlarmDefinition(name)
+	g.gameFlags.SetFlag(alarmFlagName(name))
+	state := &activeAlarm{
+		mapName:      g.currentMapName,
+		lastKnownPos: lastKnownPos,
+	}
+	g.activeAlarms[name] = state
+
+	switch {
+	case source == g.Player:
+		g.msg(foundation.HiLite("You sound the alarm!"))
+	case source != nil && g.canPlayerSee(source.Position()):
+		g.msg(foundation.HiLite("%s sounds the alarm!", source.Name()))
+	default:
+		g.msg(foundation.HiLite("An alarm goes off!"))
+	}
+
+	for _, doorName := range alarm.LockDoors {
+		door, exists := g.TryGetDoorByName(doorName)
+		if !exists || door.IsLocked() || g.currentMap().IsActorAt(door.Position()) {
+			continue
+		}
+		door.Lock()
+		state.lockedDoors = append(state.lockedDoors, doorName)
+	}
+
+	if len(alarm.Lights) > 0 {
+		for _, light := range alarm.Lights {
+			g.currentMap().SetBakedLightEnabled(light, true)
+		}
+		g.updatePlayerFoVAndApplyExploration()
+	}
+
+	if alarm.MusicFile != "" {
+		g.ui.PlayMusic(path.Join(g.config.DataRootDir, "audio", "music", alarm.MusicFile+".ogg"))
+	}
+
+	for _, actor := range g.currentMap().Actors() {
+		if !g.isAlarmResponder(alarm, actor) || actor.IsDisabled() {
+			continue
+		}
+		if actor.IsSleeping() {
+			actor.WakeUp()
+		}
+		actor.respondToAlarm(g.Player, lastKnownPos)
+	}
+	g.ui.UpdateVisibleActors()
+}
+
+// endAlarm calls off the alarm. The doors locked by the alarm are opened again and the music of the map returns.
+func (g *GameState) endAlarm(name string) {
+	state, isActive := g.activeAlarms[name]
+	if !isActive {
+		return
+	}
+	delete(g.activeAlarms, name)
+	g.gameFlags.ClearFlag(alarmFlagName(name))
+	if state.mapName != g.currentMapName {
+		return
+	}
+
+	alarm := g.getAlarmDefinition(name)
+	for _, doorName := range state.lockedDoors {
+		if door, exists := g.TryGetDoorByName(doorName); exists && door.IsLocked() {
+			door.Unlock()
+		}
+	}
+	if alarm.MusicFile != "" {
+		g.ui.PlayMusic(path.Join(g.config.DataRootDir, "audio", "music", g.currentMap().GetMeta().MusicFile+".ogg"))
+	}
+	g.msg(foundation.Msg("The alarm has been called off."))
+}
+
+// updateAlarms is called once per turn. It sends in the reinforcements
+// and calls off the alarms of the current map once nobody has seen the player for a while.
+func (g *GameState) updateAlarms() {
+	for name, state := range g.activeAlarms {
+		if state.mapName != g.currentMapName {
+			continue
+		}
+		alarm := g.getAlarmDefinition(name)
+		state.turnsActive++
+		state.turnsUnseen++
+
+		responders := g.currentMap().GetFilteredActors(func(actor *Actor) bool {
+			return g.isAlarmResponder(alarm, actor)
+		})
+		for _, responder := range responders {
+			if g.isInVisionOf(responder, g.Player.Position()) {
+				state.turnsUnseen = 0
+				state.lastKnownPos = g.Player.Position()
+				break
+			}
+		}
+		if state.turnsUnseen == 0 {
+			for _, responder := range responders {
+				if !responder.HasActiveGoal() && !responder.IsDisabled() {
+					responder.Investigate(state.lastKnownPos)
+				}
+			}
+		}
+
+		for state.wavesSpawned < len(alarm.Waves) && alarm.Waves[state.wavesSpawned].Delay <= state.turnsActive {
+			g.spawnReinforcements(alarm.Waves[state.wavesSpawned], state.lastKnownPos)
+			state.wavesSpawned++
+		}
+
+		if alarm.Timeout > 0 && state.turnsUnseen >= alarm.Timeout {
+			g.endAlarm(name)
+		}
+	}
+}
+
+// spawnReinforcements places the actors of the wave around its named location.
+// They are sent to the last known position of the player.
+func (g *GameState) spawnReinforcements(wave gridmap.AlarmWave, lastKnownPos geometry.Point) {
+	if len(wave.Actors) == 0 {
+		return
+	}
+	templates := g.loadReinforcementRecords(g.currentMapName)
+	location := g.currentMap().GetNamedLocation(wave.Location)
+	isFree := func(p geometry.Point) bool {
+		return g.currentMap().IsTileWalkable(p) && !g.currentMap().IsActorAt(p) && !g.currentMap().IsObjectAt(p)
+	}
+	var freeCells []geometry.Point
+	if isFree(location) {
+		freeCells = append(freeCells, location)
+	}
+	freeCells = append(freeCells, g.currentMap().GetFreeCellsForDistribution(location, len(wave.Actors), isFree)...)
+
+	spawned := 0
+	for _, actorName := range wave.Actors {
+		record, exists := templates[actorName]
+		if !exists || spawned >= len(freeCells) {
+			continue
+		}
+		actor, _ := g.NewActor(record)
+		spawnPos := freeCells[spawned]
+		actor.SetPosition(spawnPos)
+		actor.SpawnPosition = spawnPos
+		actor.respondToAlarm(g.Player, lastKnownPos)
+		g.currentMap().AddActor(actor, spawnPos)
+		spawned++
+	}
+	if spawned > 0 {
+		g.msg(foundation.HiLite("Reinforcements have arrived"))
+		g.ui.UpdateVisibleActors()
+	}
+}
+
+func (g *GameState) loadReinforcementRecords(mapName string) map[string]recfile.Record {
+	templates := make(map[string]recfile.Record)
+	reinforcementFile := path.Join(g.config.DataRootDir, "maps", mapName, "reinforcements.rec")
+	if !fxtools.FileExists(reinforcementFile) {
+		return templates
+	}
+	for _, record := range recfile.Read(fxtools.MustOpen(reinforcementFile)) {
+		templates[record.FindValueForKeyIgnoreCase("name")] = record
+	}
+	return templates
+}
+
+// tryRunToAlarm lets frightened NPCs run to the nearest alarm panel instead of fleeing blindly.
+func (g *GameState) tryRunToAlarm(actor *Actor) (int, bool) {
+	if !actor.HasFlag(foundation.FlagScared) || actor.IsStationary() {
+		return 0, false
+	}
+	panel := g.nearestUsableAlarmPanel(actor)
+	if panel == nil {
+		return 0, false
+	}
+	if g.currentMap().MoveDistance(actor.Position(), panel.Position()) <= 1 {
+		actor.SetFacing(directionTowards(actor.Position(), panel.Position()))
+		panel.Trigger(actor)
+		actor.GetFlags().Unset(foundation.FlagScared)
+		actor.ChangeMorale(actor.GetMaxMorale() / 4)
+		return actor.timeNeededForActions(), true
+	}
+	destination := g.currentMap().GetNearestWalkableNeighbor(actor.Position(), panel.Position())
+	tuSpent := moveTowards(g, actor, destination)
+	if actor.cannotFindPath() {
+		return 0, false
+	}
+	return tuSpent, true
+}
+
+// nearestUsableAlarmPanel is also used by frightened civilians, they just won't join the hunt afterwards.
+func (g *GameState) nearestUsableAlarmPanel(actor *Actor) *AlarmPanel {
+	if actor.IsAlliedWith(g.Player) || actor.HasFlag(foundation.FlagAnimal) {
+		return nil
+	}
+	var nearest *AlarmPanel
+	closestDistance := alarmPanelSearchRadius + 1
+	for _, obj := range g.currentMap().Objects() {
+		panel, isPanel := obj.(*AlarmPanel)
+		if !isPanel || panel.IsDisabled() || g.IsAlarmActive(panel.GetAlarmName()) {
+			continue
+		}
+		distance := geometry.DistanceChebyshev(actor.Position(), panel.Position())
+		if distance < closestDistance {
+			nearest = panel
+			closestDistance = distance
+		}
+	}
+	return nearest
+}
+
+func (g *GameState) playerSabotageAlarmPanel(panel *AlarmPanel) {
+	result := g.Player.GetCharSheet().SkillRoll(special.Mechanics, 0)
+	switch {
+	case result.Success:
+		panel.isDisabled = true
+		g.msg(foundation.HiLite("You disable %s", panel.Name()))
+	case result.Crit:
+		g.msg(foundation.HiLite("You set off %s", panel.Name()))
+		panel.Trigger(g.Player)
+	default:
+		g.msg(foundation.HiLite("You fail to disable %s", panel.Name()))
+	}
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+func (g *GameState) activeAlarmsToRecords() []recfile.Record {
+	var recs []recfile.Record
+	for name, state := range g.activeAlarms {
+		record := recfile.Record{
+			recfile.Field{Name: "name", Value: name},
+			recfile.Field{Name: "map", Value: state.mapName},
+			recfile.Field{Name: "turns", Value: recfile.IntStr(state.turnsActive)},
+			recfile.Field{Name: "unseen", Value: recfile.IntStr(state.turnsUnseen)},
+			recfile.Field{Name: "waves", Value: recfile.IntStr(state.wavesSpawned)},
+			recfile.Field{Name: "lastknown", Value: state.lastKnownPos.Encode()},
+		}
+		for _, doorName := range state.lockedDoors {
+			record = append(record, recfile.Field{Name: "lockeddoor", Value: doorName})
+		}
+		recs = append(recs, record)
+	}
+	return recs
+}
+
+func (g *GameState) activeAlarmsFromRecords(records []recfile.Record) map[string]*activeAlarm {
+	result := make(map[string]*activeAlarm)
+	for _, record := range records {
+		var name string
+		state := &activeAlarm{}
+		for _, field := range record {
+			switch strings.ToLower(field.Name) {
+			case "name":
+				name = field.Value
+			case "map":
+				state.mapName = field.Value
+			case "turns":
+				state.turnsActive = field.AsInt()
+			case "unseen":
+				state.turnsUnseen = field.AsInt()
+			case "waves":
+				state.wavesSpawned = field.AsInt()
+			case "lastknown":
+				state.lastKnownPos, _ = geometry.NewPointFromEncodedString(field.Value)
+			case "lockeddoor":
+				state.lockedDoors = append(state.lockedDoors, field.Value)
+			}
+		}
+		result[name] = state
+	}
+	return result
+}
+
+// respondToAlarm sends the actor to search for the intruder. There is no goal until the intruder is spotted,
+// IsEnemyOf still counts the actor as an enemy, so the UI, the sleep guard and the player's mines treat it as one.
+func (a *Actor) respondToAlarm(intruder *Actor, lastKnownPos geometry.Point) {
+	a.SetHostileTowards(intruder)
+	a.ChangeAlertLevel(alertLevelMax)
+	a.Investigate(lastKnownPos)
+}
@@ -0,0 +1,169 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"github.com/memmaker/go/geometry"
+	"math/rand"
+)
+
+const (
+	alertLevelMax        = 100
+	alertLevelSuspicious = 30
+	alertLevelAlerted    = 70
+	searchDuration       = 20
+	searchRadius         = 5
+)
+
+const (
+	noiseRadiusGunshot   = 15
+	noiseRadiusExplosion = 20
+	noiseRadiusImpact    = 6
+	noiseRadiusDoor      = 5
+)
+
+func (a *Actor) GetAlertLevel() int {
+	return a.alertLevel
+}
+
+func (a *Actor) GetAlertState() foundation.AlertState {
+	if a.alertLevel >= alertLevelAlerted {
+		return foundation.AlertAlerted
+	}
+	if a.alertLevel >= alertLevelSuspicious {
+		return foundation.AlertSuspicious
+	}
+	return foundation.AlertCalm
+}
+
+func (a *Actor) IsAlerted() bool {
+	return a.GetAlertState() == foundation.AlertAlerted
+}
+
+func (a *Actor) ChangeAlertLevel(delta int) {
+	a.alertLevel = max(0, min(alertLevelMax, a.alertLevel+delta))
+}
+
+// Investigate makes the actor walk to the position and search the area around it.
+func (a *Actor) Investigate(pos geometry.Point) {
+	a.investigatePosition = pos
+	a.isInvestigating = true
+	a.searchTurns = searchDuration
+	a.searchWaypoint = pos
+}
+
+func (a *Actor) StopInvestigating() {
+	a.isInvestigating = false
+	a.searchTurns = 0
+}
+
+func (a *Actor) IsInvestigating() bool {
+	return a.isInvestigating
+}
+
+// noticeTarget is called every turn the actor sees the one it's fighting.
+// The position is remembered, so the actor can search for the target once it's out of sight.
+func (g *GameState) noticeTarget(actor *Actor, target *Actor) {
+	if target == g.Player && !actor.IsAlerted() {
+		g.msg(foundation.HiLite("%s notices you", actor.Name()))
+	}
+	actor.alertLevel = alertLevelMax
+	actor.Investigate(target.Position())
+}
+
+// decayAlert lets NPCs calm down while nothing happens.
+func (g *GameState) decayAlert(actor *Actor) {
+	if actor.alertLevel == 0 || actor.IsInvestigating() {
+		return
+	}
+	actor.ChangeAlertLevel(-1)
+}
+
+// makeNoise alerts everyone who can hear it. Sound travels through open space and is stopped by walls and closed doors.
+// Sleepers may wake up from loud noises, everyone else walks over to investigate.
+func (g *GameState) makeNoise(origin geometry.Point, radius int, source *Actor) {
+	heardAt := g.currentMap().GetDijkstraMap(origin, radius, g.currentMap().IsTransparent)
+	for _, listener := range g.currentMap().Actors() {
+		if listener == g.Player || listener == source || !listener.IsAlive() || listener.IsSurrendered() {
+			continue
+		}
+		if _, canHear := heardAt[listener.Position()]; !canHear {
+			continue
+		}
+		if listener.IsSleeping() {
+			if radius < noiseRadiusGunshot || rand.Intn(2) == 0 {
+				continue
+			}
+			listener.WakeUp()
+			g.ui.AddAnimations(OneAnimation(g.ui.GetAnimWakeUp(listener.Position(), nil)))
+		}
+		if listener.HasActiveGoal() && listener.activeGoal.Kind == GoalKindKillActor {
+			// busy fighting
+			continue
+		}
+		listener.ChangeAlertLevel(radius * 3)
+		if listener.GetAlertState() != foundation.AlertCalm {
+			listener.Investigate(origin)
+		}
+	}
+}
+
+// investigate moves the actor to the remembered position, then it searches the area for a while.
+// Afterwards, the actor returns to its post.
+func (g *GameState) investigate(actor *Actor) (int, bool) {
+	if !actor.IsInvestigating() {
+		return 0, false
+	}
+	if actor.Position() != actor.investigatePosition && actor.searchTurns == searchDuration {
+		tuSpent := moveTowards(g, actor, actor.investigatePosition)
+		if actor.cannotFindPath() || tuSpent == actor.timeEnergy {
+			// can't get there, search from here
+			actor.searchTurns--
+		}
+		return tuSpent, true
+	}
+
+	actor.searchTurns--
+	if actor.searchTurns <= 0 {
+		actor.StopInvestigating()
+		actor.ChangeAlertLevel(-alertLevelSuspicious)
+		if g.canPlayerSee(actor.Position()) {
+			g.tryAddChatter(actor, "Must have been nothing.")
+		}
+		if actor.patrolRoute == "" && actor.Position() != actor.SpawnPosition {
+			actor.SetGoal(GoalMoveToSpawn())
+		}
+		return actor.timeEnergy, true
+	}
+
+	if actor.Position() == actor.searchWaypoint || actor.searchWaypoint == actor.investigatePosition {
+		actor.searchWaypoint = g.randomSearchWaypoint(actor)
+	}
+	tuSpent := moveTowards(g, actor, actor.searchWaypoint)
+	if actor.cannotFindPath() {
+		actor.searchWaypoint = actor.Position()
+	}
+	return tuSpent, true
+}
+
+func (g *GameState) randomSearchWaypoint(actor *Actor) geometry.Point {
+	reachable := g.currentMap().GetDijkstraMap(actor.investigatePosition, searchRadius, g.currentMap().IsTileWalkable)
+	candidates := make([]geometry.Point, 0, len(reachable))
+	for pos := range reachable {
+		if pos != actor.Position() {
+			candidates = append(candidates, pos)
+		}
+	}
+	if len(candidates) == 0 {
+		return actor.Position()
+	}
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// hasLostTrackOfPlayer is true if the actor fights back against the player, but can't see them anymore.
+// Kill goals from scripts are kept.
+func (g *GameState) hasLostTrackOfPlayer(actor *Actor) bool {
+	if !actor.HasActiveGoal() || !actor.activeGoal.IsReaction || actor.activeGoal.Kind != GoalKindKillActor || actor.activeGoal.Target != g.Player.GetInternalName() {
+		return false
+	}
+	return !g.isInVisionOf(actor, g.Player.Position())
+}
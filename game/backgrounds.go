@@ -0,0 +1,162 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"fmt"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/recfile"
+	"path"
+	"strings"
+)
+
+// Background is chosen when a new game starts. It replaces the default start from player_start.rec.
+// The chosen background is remembered with the flag "Background(<name>)", the reputation with the
+// flags "Reputation(<team>)" and "BadReputation(<team>)".
+type Background struct {
+	Name        string
+	Description string
+	Text        string
+	MapName     string
+	MapLocation string
+	Items       []string
+	SkillMods   map[special.Skill]int
+	Flags       []string
+	Reputation  map[string]int
+}
+
+// loadBackgrounds reads the backgrounds the player can choose from at the start of the game.
+// Items replace the default starting gear, if there are any. The start map and location are optional, eg.
+//
+//	Name: medic
+//	Description: Medic
+//	item: doctors_bag
+//	skill_bonus: biology(20)
+//	flag: KnowsTheClinic
+//	reputation: doctors(20)
+//	mapName: town
+//	mapLocation: taxi_stand
+func loadBackgrounds(dataRootDir string) []Background {
+	backgroundFile := path.Join(dataRootDir, "definitions", "backgrounds.rec")
+	if !fxtools.FileExists(backgroundFile) {
+		return nil
+	}
+	var backgrounds []Background
+	for _, record := range recfile.Read(fxtools.MustOpen(backgroundFile)) {
+		backgrounds = append(backgrounds, NewBackgroundFromRecord(record))
+	}
+	return backgrounds
+}
+
+func NewBackgroundFromRecord(record recfile.Record) Background {
+	background := Background{
+		SkillMods:  make(map[special.Skill]int),
+		Reputation: make(map[string]int),
+	}
+	for _, field := range record {
+		switch strings.ToLower(field.Name) {
+		case "name":
+			background.Name = field.Value
+		case "description":
+			background.Description = field.Value
+		case "text":
+			background.Text = field.Value
+		case "mapname":
+			background.MapName = field.Value
+		case "maplocation":
+			background.MapLocation = field.Value
+		case "item":
+			background.Items = append(background.Items, field.Value)
+		case "flag":
+			background.Flags = append(background.Flags, field.Value)
+		case "skill_bonus":
+			name, args := fxtools.GetNameAndArgs(field.Value)
+			background.SkillMods[special.SkillFromString(name)] = args.GetInt(0)
+		case "reputation":
+			team, args := fxtools.GetNameAndArgs(field.Value)
+			background.Reputation[team] = args.GetInt(0)
+		}
+	}
+	if background.Description == "" {
+		background.Description = background.Name
+	}
+	return background
+}
+
+func backgroundFlag(name string) string {
+	return fmt.Sprintf("Background(%s)", name)
+}
+
+func (g *GameState) HasBackground(name string) bool {
+	return g.gameFlags.HasFlag(backgroundFlag(name))
+}
+
+func reputationFlag(team string) string {
+	return fmt.Sprintf("Reputation(%s)", team)
+}
+
+func badReputationFlag(team string) string {
+	return fmt.Sprintf("BadReputation(%s)", team)
+}
+
+// HasReputation is true, if the background of the player is well known to the team.
+func (g *GameState) HasReputation(team string) bool {
+	return g.gameFlags.HasFlag(reputationFlag(team))
+}
+
+// HasBadReputation is true, if the team holds a grudge against the background of the player.
+func (g *GameState) HasBadReputation(team string) bool {
+	return g.gameFlags.HasFlag(badReputationFlag(team))
+}
+
+// openBackgroundMenu lets the player choose a background for a new game.
+// The menu can't be closed, the default start is one of the choices.
+func (g *GameState) openBackgroundMenu() {
+	var menuItems []foundation.MenuItem
+	for _, b := range g.backgroundCatalogue {
+		background := b
+		menuItems = append(menuItems, foundation.MenuItem{
+			Name: background.Description,
+			Action: func() {
+				g.enterDungeon(&background)
+			},
+			CloseMenus: true,
+		})
+	}
+	menuItems = append(menuItems, foundation.MenuItem{
+		Name: "No background",
+		Action: func() {
+			g.enterDungeon(nil)
+		},
+		CloseMenus: true,
+	})
+	g.ui.OpenMenuWithTitleNotClosable("Choose your background", menuItems)
+}
+
+// applyBackground is called by initPlayerAndMap, after the player has been created and before the start map is loaded.
+func (g *GameState) applyBackground(background Background) {
+	for _, itemName := range background.Items {
+		g.giveAndTryEquipItem(g.Player, g.NewItemFromString(itemName))
+	}
+
+	charSheet := g.Player.GetCharSheet()
+	for skill, value := range background.SkillMods {
+		charSheet.AddSkillPointsTo(skill, value)
+	}
+
+	g.gameFlags.SetFlag(backgroundFlag(background.Name))
+	for _, flag := range background.Flags {
+		g.gameFlags.SetFlag(flag)
+	}
+	for team, value := range background.Reputation {
+		if value < 0 {
+			g.gameFlags.Set(badReputationFlag(team), -value)
+		} else {
+			g.gameFlags.Set(reputationFlag(team), value)
+		}
+	}
+
+	if background.Text != "" {
+		g.msg(foundation.Msg(background.Text))
+	}
+}
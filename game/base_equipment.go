@@ -30,6 +30,9 @@ func (e *Equipment) GobDecode(data []byte) error {
 	if err := decoder.Decode(&e.slots); err != nil {
 		return err
 	}
+	if e.slots == nil {
+		e.slots = make(map[foundation.EquipSlot]foundation.Equippable)
+	}
 
 	return nil
 }
@@ -40,28 +43,51 @@ func NewEquipment() *Equipment {
 }
 
 func (e *Equipment) IsEquipped(item foundation.Equippable) bool {
+	_, isEquipped := e.GetSlotOf(item)
+	return isEquipped
+}
+
+// GetSlotOf finds the slot the item is equipped in.
+func (e *Equipment) GetSlotOf(item foundation.Equippable) (foundation.EquipSlot, bool) {
 	if item == nil {
-		return false
+		return foundation.SlotNameNotEquippable, false
 	}
-	slot := slotFromItem(item)
-	if slot == foundation.SlotNameNotEquippable {
-		return false
-	}
-	slotItem, exists := e.slots[slot]
-	if exists && slotItem == item {
-		return true
+	for slot, slotItem := range e.slots {
+		if slotItem == item {
+			return slot, true
+		}
 	}
-	return false
+	return foundation.SlotNameNotEquippable, false
 }
 
 func slotFromItem(item foundation.Equippable) foundation.EquipSlot {
 	if item.IsArmor() {
 		return foundation.SlotNameArmorTorso
-	} else if item.IsLightSource() {
-		return foundation.SlotNameLightSource
 	} else if item.IsWeapon() {
 		return foundation.SlotNameMainHand
 	}
+	return item.GetEquipSlot()
+}
+
+// slotForEquip is the slot an item goes into by default. Accessories and belt items use the first free slot of their kind.
+func (e *Equipment) slotForEquip(item foundation.Equippable) foundation.EquipSlot {
+	slot := slotFromItem(item)
+	switch {
+	case slot.IsAccessorySlot():
+		return e.firstFreeSlot(foundation.AccessorySlots)
+	case slot.IsBeltSlot():
+		return e.firstFreeSlot(foundation.BeltSlots)
+	}
+	return slot
+}
+
+// firstFreeSlot is SlotNameNotEquippable, if all of the slots are used. The player has to free one first.
+func (e *Equipment) firstFreeSlot(slots []foundation.EquipSlot) foundation.EquipSlot {
+	for _, slot := range slots {
+		if _, isUsed := e.slots[slot]; !isUsed {
+			return slot
+		}
+	}
 	return foundation.SlotNameNotEquippable
 }
 
@@ -69,9 +95,24 @@ func (e *Equipment) Equip(item foundation.Equippable) {
 	if item == nil {
 		return
 	}
+	e.EquipToSlot(item, e.slotForEquip(item))
+}
+
+// EquipToSlot replaces everything in the way, eg. a two-handed weapon also needs the off hand.
+func (e *Equipment) EquipToSlot(item foundation.Equippable, slot foundation.EquipSlot) {
+	if item == nil || slot == foundation.SlotNameNotEquippable {
+		return
+	}
 	defer e.changed()
-	slotName := slotFromItem(item)
-	e.slots[slotName] = item
+	if currentSlot, isEquipped := e.GetSlotOf(item); isEquipped {
+		e.unEquipBySlot(currentSlot)
+	}
+	for _, replacedItem := range e.getItemsToReplaceInSlot(item, slot) {
+		if replacedSlot, isEquipped := e.GetSlotOf(replacedItem); isEquipped {
+			e.unEquipBySlot(replacedSlot)
+		}
+	}
+	e.slots[slot] = item
 }
 
 func (e *Equipment) UnEquip(item foundation.Equippable) {
@@ -79,8 +120,7 @@ func (e *Equipment) UnEquip(item foundation.Equippable) {
 		return
 	}
 	defer e.changed()
-	slotName := slotFromItem(item)
-	if e.slots[slotName] == item {
+	if slotName, isEquipped := e.GetSlotOf(item); isEquipped {
 		e.unEquipBySlot(slotName)
 	}
 }
@@ -102,17 +142,55 @@ func (e *Equipment) GetArmor() *Armor {
 }
 
 func (e *Equipment) CanEquip(item foundation.Equippable) bool {
-	toBeReplaced := e.GetItemsToReplace(item)
-	for _, itemToRemove := range toBeReplaced {
+	return e.CanEquipToSlot(item, e.slotForEquip(item))
+}
+
+func (e *Equipment) CanEquipToSlot(item foundation.Equippable, slot foundation.EquipSlot) bool {
+	if slot == foundation.SlotNameNotEquippable {
+		return false
+	}
+	for _, itemToRemove := range e.getItemsToReplaceInSlot(item, slot) {
 		if !e.CanUnequip(itemToRemove) {
 			return false
 		}
 	}
 	return true
 }
+
 func (e *Equipment) GetItemsToReplace(item foundation.Equippable) []foundation.Equippable {
+	return e.getItemsToReplaceInSlot(item, e.slotForEquip(item))
+}
+
+// getItemsToReplaceInSlot takes care of the hands. A two-handed weapon blocks the off hand and vice versa.
+func (e *Equipment) getItemsToReplaceInSlot(item foundation.Equippable, slot foundation.EquipSlot) []foundation.Equippable {
 	var items []foundation.Equippable
-	return appendIfNotNil(items, e.slots[slotFromItem(item)])
+	if slotItem := e.slots[slot]; slotItem != item {
+		items = appendIfNotNil(items, slotItem)
+	}
+	switch slot {
+	case foundation.SlotNameMainHand:
+		if isTwoHanded(item) {
+			items = appendIfNotNil(items, e.slots[foundation.SlotNameOffHand])
+		}
+	case foundation.SlotNameOffHand:
+		if mainHandItem := e.slots[foundation.SlotNameMainHand]; isTwoHanded(mainHandItem) {
+			items = appendIfNotNil(items, mainHandItem)
+		}
+	}
+	return items
+}
+
+func isTwoHanded(item foundation.Equippable) bool {
+	weapon, isWeapon := item.(*Weapon)
+	return isWeapon && weapon.IsTwoHanded()
+}
+
+// canBeHeldInOffHand is true for one-handed weapons, light sources and shields.
+func canBeHeldInOffHand(item foundation.Equippable) bool {
+	if item.IsWeapon() {
+		return !isTwoHanded(item)
+	}
+	return item.IsLightSource() || item.GetEquipSlot() == foundation.SlotNameOffHand
 }
 
 func (e *Equipment) isOneSlotAvailable(slotOne foundation.EquipSlot, slotTwo foundation.EquipSlot) bool {
@@ -224,6 +302,55 @@ func (e *Equipment) GetAllFlags() map[foundation.ActorFlag]int {
 	return flags
 }
 
+func (e *Equipment) GetOffHandItem() (foundation.Equippable, bool) {
+	item, exists := e.slots[foundation.SlotNameOffHand]
+	return item, exists
+}
+
+// SwapHands switches the items in the main and the off hand.
+func (e *Equipment) SwapHands() bool {
+	mainHandItem, hasMainHandItem := e.slots[foundation.SlotNameMainHand]
+	offHandItem, hasOffHandItem := e.slots[foundation.SlotNameOffHand]
+	if !hasOffHandItem || (hasMainHandItem && !canBeHeldInOffHand(mainHandItem)) {
+		return false
+	}
+	defer e.changed()
+	delete(e.slots, foundation.SlotNameMainHand)
+	delete(e.slots, foundation.SlotNameOffHand)
+	e.slots[foundation.SlotNameMainHand] = offHandItem
+	if hasMainHandItem {
+		e.slots[foundation.SlotNameOffHand] = mainHandItem
+	}
+	return true
+}
+
+func (e *Equipment) GetBeltItem(index int) (foundation.Equippable, bool) {
+	if index < 0 || index >= len(foundation.BeltSlots) {
+		return nil, false
+	}
+	item, exists := e.slots[foundation.BeltSlots[index]]
+	return item, exists
+}
+
+// HasLightSource checks the light source slot and the off hand.
+func (e *Equipment) HasLightSource() bool {
+	for _, slot := range []foundation.EquipSlot{foundation.SlotNameLightSource, foundation.SlotNameOffHand} {
+		if item, exists := e.slots[slot]; exists && item.IsLightSource() {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Equipment) HasWatch() bool {
+	for _, slot := range foundation.AccessorySlots {
+		if item, exists := e.slots[slot]; exists && item.IsWatch() {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Equipment) GetMainHandItem() (foundation.Equippable, bool) {
 	item, exists := e.slots[foundation.SlotNameMainHand]
 	return item, exists
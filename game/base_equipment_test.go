@@ -0,0 +1,74 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"testing"
+)
+
+func testWeapon(name string, weaponType WeaponType) *Weapon {
+	return &Weapon{GenericItem: &GenericItem{internalName: name}, weaponType: weaponType}
+}
+
+func testItemInSlot(name string, slot foundation.EquipSlot) *GenericItem {
+	return &GenericItem{internalName: name, equipSlot: slot}
+}
+
+func TestEquipmentHands(t *testing.T) {
+	pistol := testWeapon("pistol", WeaponTypePistol)
+	knife := testWeapon("knife", WeaponTypeKnife)
+	rifle := testWeapon("rifle", WeaponTypeRifle)
+	shield := testItemInSlot("shield", foundation.SlotNameOffHand)
+
+	tests := []struct {
+		name         string
+		mainHand     foundation.Equippable
+		offHand      foundation.Equippable
+		equip        foundation.Equippable
+		slot         foundation.EquipSlot
+		wantMainHand foundation.Equippable
+		wantOffHand  foundation.Equippable
+	}{
+		{"two-handed weapon replaces both hands", pistol, shield, rifle, foundation.SlotNameMainHand, rifle, nil},
+		{"off hand item replaces a two-handed weapon", rifle, nil, shield, foundation.SlotNameOffHand, nil, shield},
+		{"one-handed weapon keeps the off hand", pistol, shield, knife, foundation.SlotNameMainHand, knife, shield},
+		{"second weapon in the off hand", pistol, nil, knife, foundation.SlotNameOffHand, pistol, knife},
+		{"moving the main hand weapon to the off hand", pistol, nil, pistol, foundation.SlotNameOffHand, nil, pistol},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equipment := NewEquipment()
+			if tt.mainHand != nil {
+				equipment.EquipToSlot(tt.mainHand, foundation.SlotNameMainHand)
+			}
+			if tt.offHand != nil {
+				equipment.EquipToSlot(tt.offHand, foundation.SlotNameOffHand)
+			}
+			equipment.EquipToSlot(tt.equip, tt.slot)
+			if got := equipment.GetBySlot(foundation.SlotNameMainHand); got != tt.wantMainHand {
+				t.Errorf("main hand = %v, want %v", got, tt.wantMainHand)
+			}
+			if got := equipment.GetBySlot(foundation.SlotNameOffHand); got != tt.wantOffHand {
+				t.Errorf("off hand = %v, want %v", got, tt.wantOffHand)
+			}
+		})
+	}
+}
+
+func TestEquipmentRefusesFullAccessorySlots(t *testing.T) {
+	equipment := NewEquipment()
+	for _, name := range []string{"ring", "badge"} {
+		item := testItemInSlot(name, foundation.SlotNameAccessoryOne)
+		if !equipment.CanEquip(item) {
+			t.Fatalf("%s should fit into a free accessory slot", name)
+		}
+		equipment.Equip(item)
+	}
+	watch := testItemInSlot("watch", foundation.SlotNameAccessoryOne)
+	if equipment.CanEquip(watch) {
+		t.Errorf("a third accessory should not fit")
+	}
+	equipment.Equip(watch)
+	if equipment.IsEquipped(watch) {
+		t.Errorf("equipping a third accessory should not replace one of the others")
+	}
+}
@@ -15,6 +15,7 @@ type Inventory struct {
 	maxItemStacks  int
 	onChanged      func()
 	onBeforeRemove func(equippableItem foundation.Equippable)
+	isEquipped     func(equippableItem foundation.Equippable) bool
 	getCarrierPos  func() geometry.Point
 }
 
@@ -57,6 +58,16 @@ func NewInventory(maxItemStacks int, position func() geometry.Point) *Inventory
 func (i *Inventory) SetOnBeforeRemove(onBeforeRemove func(equippable foundation.Equippable)) {
 	i.onBeforeRemove = onBeforeRemove
 }
+
+// SetIsEquippedTest makes equippable items apply their modifiers only while they are equipped.
+func (i *Inventory) SetIsEquippedTest(isEquipped func(equippable foundation.Equippable) bool) {
+	i.isEquipped = isEquipped
+}
+
+func (i *Inventory) isUnequipped(item foundation.Item) bool {
+	return item.IsEquippable() && i.isEquipped != nil && !i.isEquipped(item)
+}
+
 func (i *Inventory) Items() []foundation.Item {
 	return i.items
 }
@@ -314,15 +325,6 @@ func (i *Inventory) HasStealableItems(isStealable func(item foundation.Equippabl
 	return false
 }
 
-func (i *Inventory) HasLightSource() bool {
-	for _, invItem := range i.items {
-		if invItem.IsLightSource() {
-			return true
-		}
-	}
-	return false
-}
-
 func (i *Inventory) GetTotalWeight() int {
 	totalWeight := 0
 	for _, invItem := range i.items {
@@ -364,7 +366,7 @@ func (i *Inventory) GetSkillModifiersFromItems(skill special.Skill) []special.Mo
 	var modifiers []special.Modifier
 	for _, invItem := range i.items {
 		first := invItem
-		if first.IsSkillBook() || first.IsConsumable() {
+		if first.IsSkillBook() || first.IsConsumable() || i.isUnequipped(first) {
 			continue
 		}
 		if modValue, hasValue := first.GetSkillMod(skill); hasValue {
@@ -383,7 +385,7 @@ func (i *Inventory) GetStatModifiersFromItems(stat special.Stat) []special.Modif
 	var modifiers []special.Modifier
 	for _, invItem := range i.items {
 		first := invItem
-		if first.IsConsumable() {
+		if first.IsConsumable() || i.isUnequipped(first) {
 			continue
 		}
 		if modValue, hasValue := first.GetStatMod(stat); hasValue {
@@ -401,7 +403,7 @@ func (i *Inventory) GetDerivedStatModifiersFromItems(stat special.DerivedStat) [
 	var modifiers []special.Modifier
 	for _, invItem := range i.items {
 		first := invItem
-		if first.IsConsumable() {
+		if first.IsConsumable() || i.isUnequipped(first) {
 			continue
 		}
 		if modValue, hasValue := first.GetDerivedStatMod(stat); hasValue {
@@ -432,15 +434,6 @@ func (i *Inventory) HasWeapon() bool {
 	return false
 }
 
-func (i *Inventory) HasWatch() bool {
-	for _, invItem := range i.items {
-		if invItem.IsWatch() {
-			return true
-		}
-	}
-	return false
-}
-
 func (i *Inventory) RemoveItemsByNameAndCount(name string, count int) []foundation.Item {
 	itemsToRemove := make([]foundation.Item, 0)
 	splitItems := make([]foundation.Item, 0)
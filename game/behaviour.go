@@ -0,0 +1,314 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"fmt"
+	"github.com/Knetic/govaluate"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"math/rand"
+	"path"
+	"strings"
+)
+
+type BehaviourStatus uint8
+
+const (
+	BehaviourSuccess BehaviourStatus = iota
+	BehaviourFailure
+	BehaviourRunning
+)
+
+type BehaviourNodeType uint8
+
+const (
+	// NodeSequence runs its children in order until one of them doesn't succeed
+	NodeSequence BehaviourNodeType = iota
+	// NodeSelector runs its children in order until one of them doesn't fail
+	NodeSelector
+	// NodeCondition succeeds if its expression evaluates to true
+	NodeCondition
+	// NodeAction runs a built-in AI action or any script function
+	NodeAction
+	// NodeInverter swaps success and failure of its only child
+	NodeInverter
+)
+
+func BehaviourNodeTypeFromString(s string) BehaviourNodeType {
+	switch strings.ToLower(s) {
+	case "sequence":
+		return NodeSequence
+	case "selector":
+		return NodeSelector
+	case "condition":
+		return NodeCondition
+	case "inverter", "not":
+		return NodeInverter
+	}
+	return NodeAction
+}
+
+type BehaviourNode struct {
+	Name      string
+	Type      BehaviourNodeType
+	Children  []*BehaviourNode
+	Condition *govaluate.EvaluableExpression
+	Action    string
+}
+
+// BehaviourTree is shared by all actors using it.
+// It is evaluated from the root on every AI turn, so it has no state of its own.
+// Anything an actor needs to remember between turns goes into the actor's blackboard.
+type BehaviourTree struct {
+	Name string
+	Root *BehaviourNode
+}
+
+// NewBehaviourTreeFromRecords builds a tree from a list of node records.
+// The first record is the root, children are referenced by their name.
+func NewBehaviourTreeFromRecords(name string, records []recfile.Record, scriptFuncs map[string]govaluate.ExpressionFunction) (*BehaviourTree, error) {
+	nodes := make(map[string]*BehaviourNode)
+	childNames := make(map[string][]string)
+	var root *BehaviourNode
+	for _, record := range records {
+		node := &BehaviourNode{}
+		for _, field := range record {
+			switch strings.ToLower(field.Name) {
+			case "name":
+				node.Name = field.Value
+			case "type":
+				node.Type = BehaviourNodeTypeFromString(field.Value)
+			case "child":
+				childNames[node.Name] = append(childNames[node.Name], field.Value)
+			case "condition":
+				expr, err := govaluate.NewEvaluableExpressionWithFunctions(field.Value, scriptFuncs)
+				if err != nil {
+					return nil, err
+				}
+				node.Condition = expr
+			case "action":
+				node.Action = field.Value
+			}
+		}
+		nodes[node.Name] = node
+		if root == nil {
+			root = node
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("behaviour tree %s has no nodes", name)
+	}
+	for parentName, children := range childNames {
+		parent := nodes[parentName]
+		for _, childName := range children {
+			child, exists := nodes[childName]
+			if !exists {
+				return nil, fmt.Errorf("behaviour tree %s: node %s references unknown child %s", name, parentName, childName)
+			}
+			parent.Children = append(parent.Children, child)
+		}
+	}
+	return &BehaviourTree{Name: name, Root: root}, nil
+}
+
+func (g *GameState) getBehaviourTree(name string) *BehaviourTree {
+	if tree, isLoaded := g.behaviourTrees[name]; isLoaded {
+		return tree
+	}
+	filename := path.Join(g.config.DataRootDir, "behaviours", name+".rec")
+	if !fxtools.FileExists(filename) {
+		g.behaviourTrees[name] = nil
+		return nil
+	}
+	tree, err := NewBehaviourTreeFromRecords(name, recfile.Read(fxtools.MustOpen(filename)), g.getScriptFuncs())
+	if err != nil {
+		panic(err)
+	}
+	g.behaviourTrees[name] = tree
+	return tree
+}
+
+// tickBehaviour runs the behaviour tree of the actor once.
+// Returns false if the actor has no tree or the tree failed, so the default AI can take over.
+func (g *GameState) tickBehaviour(actor *Actor) (int, bool) {
+	if actor.behaviourName == "" {
+		return 0, false
+	}
+	tree := g.getBehaviourTree(actor.behaviourName)
+	if tree == nil {
+		return 0, false
+	}
+	status, tuSpent := g.tickBehaviourNode(tree.Root, actor)
+	if status == BehaviourFailure {
+		return tuSpent, tuSpent > 0
+	}
+	if tuSpent <= 0 {
+		return actor.timeEnergy, true
+	}
+	return tuSpent, true
+}
+
+// tickBehaviourNode stops at the first node that spent time, the rest of the tree runs on the next turn.
+func (g *GameState) tickBehaviourNode(node *BehaviourNode, actor *Actor) (BehaviourStatus, int) {
+	switch node.Type {
+	case NodeSequence:
+		for _, child := range node.Children {
+			status, tuSpent := g.tickBehaviourNode(child, actor)
+			if status != BehaviourSuccess || tuSpent > 0 {
+				return status, tuSpent
+			}
+		}
+		return BehaviourSuccess, 0
+	case NodeSelector:
+		for _, child := range node.Children {
+			status, tuSpent := g.tickBehaviourNode(child, actor)
+			if status != BehaviourFailure || tuSpent > 0 {
+				return status, tuSpent
+			}
+		}
+		return BehaviourFailure, 0
+	case NodeInverter:
+		if len(node.Children) == 0 {
+			return BehaviourFailure, 0
+		}
+		status, tuSpent := g.tickBehaviourNode(node.Children[0], actor)
+		switch status {
+		case BehaviourSuccess:
+			return BehaviourFailure, tuSpent
+		case BehaviourFailure:
+			return BehaviourSuccess, tuSpent
+		}
+		return status, tuSpent
+	case NodeCondition:
+		if node.Condition == nil {
+			return BehaviourSuccess, 0
+		}
+		result, err := node.Condition.Evaluate(g.behaviourVariables(actor))
+		if err != nil {
+			panic(err)
+		}
+		if isTrue, isBool := result.(bool); isBool && isTrue {
+			return BehaviourSuccess, 0
+		}
+		return BehaviourFailure, 0
+	}
+	return g.runBehaviourAction(node.Action, actor)
+}
+
+func (g *GameState) behaviourVariables(actor *Actor) map[string]interface{} {
+	return map[string]interface{}{
+		"self":   actor,
+		"player": g.Player,
+	}
+}
+
+// runBehaviourAction executes one of the built-in AI actions.
+// Anything else is evaluated as a script expression that succeeds without spending time.
+func (g *GameState) runBehaviourAction(action string, actor *Actor) (BehaviourStatus, int) {
+	if !fxtools.LooksLikeAFunction(action) {
+		action = action + "()"
+	}
+	name, args := fxtools.GetNameAndArgs(action)
+	switch name {
+	case "Wait":
+		return BehaviourSuccess, actor.timeEnergy
+	case "Wander":
+		direction := geometry.RandomDirection()
+		targetPos := actor.Position().Add(direction.ToPoint())
+		if rand.Intn(3) != 0 || !g.currentMap().IsCurrentlyPassable(targetPos) {
+			return BehaviourSuccess, actor.timeEnergy
+		}
+		g.ui.AddAnimations(g.actorMoveAnimated(actor, targetPos))
+		return BehaviourSuccess, actor.timeNeededForMovement()
+	case "MoveToSpawn":
+		return g.behaviourMoveTo(actor, actor.SpawnPosition)
+	case "MoveToLocation":
+		return g.behaviourMoveTo(actor, g.currentMap().GetNamedLocation(args.Get(0)))
+	case "MoveToActor":
+		target := g.actorWithName(args.Get(0))
+		if target == nil || !target.IsAlive() {
+			return BehaviourFailure, 0
+		}
+		if geometry.DistanceChebyshev(actor.Position(), target.Position()) <= 1 {
+			return BehaviourSuccess, 0
+		}
+		return BehaviourRunning, moveTowards(g, actor, target.Position())
+	case "Flee":
+		newPos := g.currentMap().GetMoveOnPlayerDijkstraMap(actor.Position(), false, g.playerDijkstraMap)
+		if newPos == actor.Position() {
+			return BehaviourFailure, 0
+		}
+		g.ui.AddAnimations(g.actorMoveAnimated(actor, newPos))
+		return BehaviourRunning, actor.timeNeededForMovement()
+	case "Fight":
+		if !actor.WantsToAttack(g.Player) || !g.Player.IsAlive() {
+			return BehaviourFailure, 0
+		}
+		// a single attack or step, the tree decides again on the next turn
+		return BehaviourRunning, tryKill(g, actor, g.Player)
+	case "Fetch":
+		return g.behaviourFetch(actor, args.Get(0))
+	case "Remember":
+		actor.SetBlackboard(args.Get(0), args.Get(1))
+		return BehaviourSuccess, 0
+	case "Forget":
+		actor.SetBlackboard(args.Get(0), "")
+		return BehaviourSuccess, 0
+	}
+
+	expr, parseErr := govaluate.NewEvaluableExpressionWithFunctions(action, g.getScriptFuncs())
+	if parseErr != nil {
+		panic(parseErr)
+	}
+	result, evalErr := expr.Evaluate(g.behaviourVariables(actor))
+	if evalErr != nil {
+		panic(evalErr)
+	}
+	if isTrue, isBool := result.(bool); isBool && !isTrue {
+		return BehaviourFailure, 0
+	}
+	return BehaviourSuccess, 0
+}
+
+func (g *GameState) behaviourMoveTo(actor *Actor, targetPos geometry.Point) (BehaviourStatus, int) {
+	if actor.Position() == targetPos {
+		return BehaviourSuccess, 0
+	}
+	tuSpent := moveTowards(g, actor, targetPos)
+	if actor.cannotFindPath() {
+		return BehaviourFailure, tuSpent
+	}
+	return BehaviourRunning, tuSpent
+}
+
+// behaviourFetch walks to the nearest item with the given name and picks it up.
+func (g *GameState) behaviourFetch(actor *Actor, itemName string) (BehaviourStatus, int) {
+	var nearestItem foundation.Item
+	nearestDistance := -1
+	for _, item := range g.currentMap().Items() {
+		if item.InternalName() != itemName {
+			continue
+		}
+		distance := geometry.DistanceChebyshev(actor.Position(), item.Position())
+		if nearestDistance < 0 || distance < nearestDistance {
+			nearestItem = item
+			nearestDistance = distance
+		}
+	}
+	if nearestItem == nil {
+		return BehaviourFailure, 0
+	}
+	if actor.Position() != nearestItem.Position() {
+		return g.behaviourMoveTo(actor, nearestItem.Position())
+	}
+	if actor.GetInventory().IsFull() {
+		return BehaviourFailure, 0
+	}
+	g.currentMap().RemoveItem(nearestItem)
+	actor.GetInventory().AddItem(nearestItem)
+	if g.canPlayerSee(actor.Position()) {
+		g.msg(foundation.HiLite("%s picks up %s", actor.Name(), nearestItem.Name()))
+	}
+	return BehaviourSuccess, actor.timeNeededForActions()
+}
@@ -0,0 +1,159 @@
+package game
+
+import (
+	"github.com/Knetic/govaluate"
+	"github.com/memmaker/go/recfile"
+	"testing"
+)
+
+func behaviourRecord(name string, nodeType string, fields ...recfile.Field) recfile.Record {
+	record := recfile.Record{{Name: "Name", Value: name}, {Name: "Type", Value: nodeType}}
+	return append(record, fields...)
+}
+
+func behaviourChild(name string) recfile.Field {
+	return recfile.Field{Name: "Child", Value: name}
+}
+
+func behaviourCondition(expression string) recfile.Field {
+	return recfile.Field{Name: "Condition", Value: expression}
+}
+
+func behaviourAction(call string) recfile.Field {
+	return recfile.Field{Name: "Action", Value: call}
+}
+
+func TestNewBehaviourTreeFromRecords(t *testing.T) {
+	tree, err := NewBehaviourTreeFromRecords("guard", []recfile.Record{
+		behaviourRecord("root", "selector", behaviourChild("fight"), behaviourChild("idle")),
+		behaviourRecord("fight", "sequence", behaviourChild("not_calm"), behaviourChild("attack")),
+		behaviourRecord("not_calm", "not", behaviourChild("calm")),
+		behaviourRecord("calm", "condition", behaviourCondition("true")),
+		behaviourRecord("attack", "action", behaviourAction("Fight")),
+		behaviourRecord("idle", "action", behaviourAction("Wait")),
+	}, map[string]govaluate.ExpressionFunction{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	root := tree.Root
+	if root.Name != "root" || root.Type != NodeSelector || len(root.Children) != 2 {
+		t.Fatalf("unexpected root %+v", root)
+	}
+	fight := root.Children[0]
+	if fight.Type != NodeSequence || len(fight.Children) != 2 {
+		t.Fatalf("unexpected sequence %+v", fight)
+	}
+	inverter := fight.Children[0]
+	if inverter.Type != NodeInverter || len(inverter.Children) != 1 || inverter.Children[0].Type != NodeCondition {
+		t.Errorf("unexpected inverter %+v", inverter)
+	}
+	if attack := fight.Children[1]; attack.Type != NodeAction || attack.Action != "Fight" {
+		t.Errorf("unexpected action %+v", attack)
+	}
+}
+
+func TestNewBehaviourTreeFromRecordsRejectsBrokenTrees(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []recfile.Record
+	}{
+		{"no nodes", nil},
+		{"unknown child", []recfile.Record{behaviourRecord("root", "selector", behaviourChild("missing"))}},
+		{"broken condition", []recfile.Record{behaviourRecord("root", "condition", behaviourCondition("(("))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewBehaviourTreeFromRecords("broken", tt.records, map[string]govaluate.ExpressionFunction{}); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestTickBehaviourNode(t *testing.T) {
+	tests := []struct {
+		name       string
+		records    []recfile.Record
+		wantStatus BehaviourStatus
+		wantTU     int
+		wantPath   string
+	}{
+		{
+			name: "sequence runs all children",
+			records: []recfile.Record{
+				behaviourRecord("root", "sequence", behaviourChild("yes"), behaviourChild("mark")),
+				behaviourRecord("yes", "condition", behaviourCondition("true")),
+				behaviourRecord("mark", "action", behaviourAction("Remember(path, sequence)")),
+			},
+			wantStatus: BehaviourSuccess,
+			wantPath:   "sequence",
+		},
+		{
+			name: "sequence stops at a failed condition",
+			records: []recfile.Record{
+				behaviourRecord("root", "sequence", behaviourChild("no"), behaviourChild("mark")),
+				behaviourRecord("no", "condition", behaviourCondition("false")),
+				behaviourRecord("mark", "action", behaviourAction("Remember(path, sequence)")),
+			},
+			wantStatus: BehaviourFailure,
+		},
+		{
+			name: "selector skips failed children",
+			records: []recfile.Record{
+				behaviourRecord("root", "selector", behaviourChild("no"), behaviourChild("mark")),
+				behaviourRecord("no", "condition", behaviourCondition("false")),
+				behaviourRecord("mark", "action", behaviourAction("Remember(path, selector)")),
+			},
+			wantStatus: BehaviourSuccess,
+			wantPath:   "selector",
+		},
+		{
+			name: "selector fails if all children fail",
+			records: []recfile.Record{
+				behaviourRecord("root", "selector", behaviourChild("no"), behaviourChild("also_no")),
+				behaviourRecord("no", "condition", behaviourCondition("false")),
+				behaviourRecord("also_no", "condition", behaviourCondition("1 > 2")),
+			},
+			wantStatus: BehaviourFailure,
+		},
+		{
+			name: "inverter turns failure into success",
+			records: []recfile.Record{
+				behaviourRecord("root", "sequence", behaviourChild("not_no"), behaviourChild("mark")),
+				behaviourRecord("not_no", "inverter", behaviourChild("no")),
+				behaviourRecord("no", "condition", behaviourCondition("false")),
+				behaviourRecord("mark", "action", behaviourAction("Remember(path, inverted)")),
+			},
+			wantStatus: BehaviourSuccess,
+			wantPath:   "inverted",
+		},
+		{
+			name: "the rest of the tree waits for the next turn after spending time",
+			records: []recfile.Record{
+				behaviourRecord("root", "sequence", behaviourChild("wait"), behaviourChild("mark")),
+				behaviourRecord("wait", "action", behaviourAction("Wait")),
+				behaviourRecord("mark", "action", behaviourAction("Remember(path, after_wait)")),
+			},
+			wantStatus: BehaviourSuccess,
+			wantTU:     10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := NewBehaviourTreeFromRecords("test", tt.records, map[string]govaluate.ExpressionFunction{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			g := &GameState{}
+			actor := NewActor()
+			actor.timeEnergy = 10
+			status, tuSpent := g.tickBehaviourNode(tree.Root, actor)
+			if status != tt.wantStatus || tuSpent != tt.wantTU {
+				t.Errorf("tick = (%d, %d), want (%d, %d)", status, tuSpent, tt.wantStatus, tt.wantTU)
+			}
+			if path := actor.GetBlackboard("path"); path != tt.wantPath {
+				t.Errorf("blackboard path = %q, want %q", path, tt.wantPath)
+			}
+		})
+	}
+}
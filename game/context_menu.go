@@ -18,7 +18,22 @@ func (g *GameState) appendContextActionsForActor(buffer []foundation.MenuItem, a
         })
     }
 
-    if actor.IsHostileTowards(g.Player) || distance > 1 {
+    if actor.IsMechanical() {
+        return g.appendContextActionsForMachine(buffer, actor)
+    }
+
+    if actor.IsEnemyOf(g.Player) && !actor.IsSurrendered() && g.canPlayerSee(actor.Position()) {
+        intimidateChance := fmt.Sprintf("%d%% vs %d%%", g.Player.GetCharSheet().GetSkill(special.Intimidate), actor.GetMorale())
+        buffer = append(buffer, foundation.MenuItem{
+            Name: fmt.Sprintf("Intimidate (%s)", intimidateChance),
+            Action: func() {
+                g.playerIntimidate(actor)
+            },
+            CloseMenus: true,
+        })
+    }
+
+    if actor.IsEnemyOf(g.Player) || distance > 1 {
         return buffer
     }
 
@@ -76,8 +91,8 @@ func (g *GameState) appendContextActionsForActor(buffer []foundation.MenuItem, a
     if g.Player.GetEquipment().HasMeleeWeaponEquipped() {
         label := "Backstab"
         if !actor.IsSleeping() {
-            stabChanceString := formatContestSkSt(g.Player, actor, special.Stealth, special.Perception)
-            label = fmt.Sprintf("Backstab (%s)", stabChanceString)
+            stealth, awareness := g.backstabChances(actor)
+            label = fmt.Sprintf("Backstab (%d%% vs %d%%)", int(stealth), int(awareness))
         }
         buffer = append(buffer, foundation.MenuItem{
             Name: label,
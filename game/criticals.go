@@ -0,0 +1,135 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"math/rand"
+)
+
+const (
+	knockDownTurns        = 2
+	knockOutTurns         = 6
+	criticalBlindTurns    = 5
+	crippledHeadConfusion = 4
+)
+
+// criticalChance is the chance that a successful hit roll is also a critical hit.
+// Aimed attacks at small body parts are more likely to be critical.
+func criticalChance(attacker *Actor, part special.BodyPart) special.Percentage {
+	return special.Percentage(attacker.GetCharSheet().GetDerivedStat(special.CriticalChance) + part.CriticalChanceBonus())
+}
+
+// criticalHitFor decides how bad a critical hit is, the hit roll already decided that it is critical.
+// Criticals against small body parts are worse.
+func (g *GameState) criticalHitFor(victim *Actor, part special.BodyPart) special.CriticalHit {
+	part = victim.mainBodyPart(part)
+	severity := special.RollCriticalSeverity(part.CriticalChanceBonus())
+	return part.CriticalHitFor(severity)
+}
+
+func (g *GameState) criticalHitMessage(victim *Actor, crit special.CriticalHit) {
+	victimName := victim.Name()
+	if victim == g.Player {
+		victimName = "you"
+	}
+	g.msg(foundation.HiLite("Critical hit! "+crit.Message, victimName))
+}
+
+// applyCriticalEffects is called after the damage of the critical hit has been dealt.
+func (g *GameState) applyCriticalEffects(victim *Actor, part special.BodyPart, crit special.CriticalHit) {
+	if !victim.IsAlive() {
+		return
+	}
+	part = victim.mainBodyPart(part)
+	flags := victim.GetFlags()
+
+	if crit.Effects.Has(special.CritCripple) && victim.crippleBodyPart(part) {
+		g.onBodyPartCrippled(victim, part)
+	}
+
+	if crit.Effects.Has(special.CritBlind) {
+		flags.Increase(foundation.FlagDazzled, criticalBlindTurns)
+	}
+
+	if crit.Effects.Has(special.CritDropWeapon) {
+		g.knockWeaponAway(victim)
+	}
+
+	switch {
+	case crit.Effects.Has(special.CritKnockOut):
+		g.knockOut(victim)
+	case crit.Effects.Has(special.CritKnockDown) && !victim.IsStationary() && !victim.IsMechanical():
+		flags.Increase(foundation.FlagKnockedDown, knockDownTurns)
+	}
+}
+
+// knockOut puts NPCs to sleep, like a takedown. Machines short-circuit and the player is down for a few turns.
+func (g *GameState) knockOut(victim *Actor) {
+	switch {
+	case victim.IsMechanical():
+		victim.GetFlags().Increase(foundation.FlagEMPDisabled, knockOutTurns)
+		victim.StopInvestigating()
+	case victim == g.Player:
+		victim.GetFlags().Increase(foundation.FlagKnockedDown, knockOutTurns)
+	default:
+		victim.SetSleeping()
+	}
+}
+
+func (g *GameState) knockWeaponAway(victim *Actor) {
+	weapon, hasWeapon := victim.GetEquipment().GetMainHandWeapon()
+	if !hasWeapon || victim.IsMechanical() {
+		return
+	}
+	victim.GetEquipment().UnEquip(weapon)
+	g.removeItemFromInventory(victim, weapon)
+	g.addItemToMap(weapon, victim.Position())
+}
+
+// onBodyPartCrippled applies the lasting consequences of a crippled body part, that are not covered by the modifiers.
+func (g *GameState) onBodyPartCrippled(victim *Actor, part special.BodyPart) {
+	switch part {
+	case special.Head:
+		victim.GetFlags().Increase(foundation.FlagConfused, crippledHeadConfusion)
+	case special.Arms:
+		if weapon, hasWeapon := victim.GetEquipment().GetMainHandWeapon(); hasWeapon && weapon.IsTwoHanded() {
+			victim.GetEquipment().UnEquip(weapon)
+			if victim == g.Player {
+				g.msg(foundation.HiLite("You can't hold %s with a crippled arm", weapon.Name()))
+			}
+		}
+	}
+}
+
+// crippleBodyPart is true, if the body part was not crippled before.
+func (a *Actor) crippleBodyPart(part special.BodyPart) bool {
+	if a.IsCrippled(part) {
+		return false
+	}
+	a.bodyDamage[part] = part.DamageForCrippled(a.GetHitPointsMax()) + 1
+	return true
+}
+
+// GetInjuryStatModifiers are the penalties for crippled body parts.
+// Crippling depends on the hit points, so the stats that change the hit points must not depend on crippling.
+func (a *Actor) GetInjuryStatModifiers(stat special.Stat) []special.Modifier {
+	var result []special.Modifier
+	switch stat {
+	case special.Perception:
+		if a.IsCrippled(special.Eyes) || a.IsCrippled(special.Sensors) {
+			result = append(result, special.DefaultModifier{Source: "Crippled Eyes", Modifier: -3, Order: 1})
+		}
+	case special.Agility:
+		if a.IsCrippled(special.Groin) {
+			result = append(result, special.DefaultModifier{Source: "Crippled Groin", Modifier: -2, Order: 1})
+		}
+	}
+	return result
+}
+
+// applyInjuryEffects is called once per turn. A crippled head causes fits of confusion.
+func (a *Actor) applyInjuryEffects() {
+	if a.IsCrippled(special.Head) && !a.HasFlag(foundation.FlagConfused) && rand.Intn(20) == 0 {
+		a.statusFlags.Increase(foundation.FlagConfused, crippledHeadConfusion)
+	}
+}
@@ -1,18 +1,317 @@
 package game
 
-import "strings"
+import (
+	"RogueUI/special"
+	"fmt"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/recfile"
+	"path"
+	"strings"
+)
 
-type CyberWare int
+const (
+	implantEnergyBase            = 20
+	implantEnergyPerEndurance    = 5
+	implantRechargeInterval      = 3
+	implantSurgeryDamage         = 5
+	implantEMPDurationMultiplier = 3
+)
+
+type ImplantSlot int
 
 const (
-	CyberWareInvalid CyberWare = -1
-	CyberWareLight   CyberWare = iota
+	ImplantSlotEyes ImplantSlot = iota
+	ImplantSlotArms
+	ImplantSlotSpine
+	ImplantSlotSubdermal
+	ImplantSlotBrain
+	ImplantSlotInvalid ImplantSlot = -1
 )
 
-func NewCyberWareFromString(s string) CyberWare {
+func ImplantSlotFromString(s string) ImplantSlot {
 	switch strings.ToLower(s) {
-	case "light":
-		return CyberWareLight
+	case "eyes":
+		return ImplantSlotEyes
+	case "arms":
+		return ImplantSlotArms
+	case "spine":
+		return ImplantSlotSpine
+	case "subdermal":
+		return ImplantSlotSubdermal
+	case "brain":
+		return ImplantSlotBrain
+	}
+	return ImplantSlotInvalid
+}
+
+func (s ImplantSlot) String() string {
+	switch s {
+	case ImplantSlotEyes:
+		return "Eyes"
+	case ImplantSlotArms:
+		return "Arms"
+	case ImplantSlotSpine:
+		return "Spine"
+	case ImplantSlotSubdermal:
+		return "Subdermal"
+	case ImplantSlotBrain:
+		return "Brain"
+	}
+	return "Invalid"
+}
+
+// BodyPart is the body part that gets crippled, when the surgery goes wrong.
+func (s ImplantSlot) BodyPart() special.BodyPart {
+	switch s {
+	case ImplantSlotEyes:
+		return special.Eyes
+	case ImplantSlotArms:
+		return special.Arms
+	case ImplantSlotBrain:
+		return special.Head
+	}
+	return special.Body
+}
+
+// ImplantAbility is an active ability of an implant. Most of them are just an additional StatChange while active,
+// night vision is checked directly when updating the light sources.
+type ImplantAbility int
+
+const (
+	ImplantAbilityNone ImplantAbility = iota
+	ImplantAbilityNightVision
+	ImplantAbilityReflexBoost
+	ImplantAbilityDermalArmor
+	ImplantAbilityHackingAssist
+)
+
+func ImplantAbilityFromString(s string) ImplantAbility {
+	switch strings.ReplaceAll(strings.ToLower(s), "_", "") {
+	case "nightvision":
+		return ImplantAbilityNightVision
+	case "reflexboost":
+		return ImplantAbilityReflexBoost
+	case "dermalarmor":
+		return ImplantAbilityDermalArmor
+	case "hackingassist":
+		return ImplantAbilityHackingAssist
+	}
+	return ImplantAbilityNone
+}
+
+func (a ImplantAbility) String() string {
+	switch a {
+	case ImplantAbilityNightVision:
+		return "Night Vision"
+	case ImplantAbilityReflexBoost:
+		return "Reflex Boost"
+	case ImplantAbilityDermalArmor:
+		return "Dermal Armor"
+	case ImplantAbilityHackingAssist:
+		return "Hacking Assist"
+	}
+	return "None"
+}
+
+// Implant is a definition from the implant catalogue. The passive bonuses are always applied,
+// the active bonuses only while the ability is switched on. Active abilities drain the implant energy every turn.
+type Implant struct {
+	Name              string
+	Description       string
+	Slot              ImplantSlot
+	Passive           StatChange
+	Ability           ImplantAbility
+	ActiveBonus       StatChange
+	EnergyCost        int
+	InstallDifficulty int
+}
+
+func (i Implant) HasAbility() bool {
+	return i.Ability != ImplantAbilityNone
+}
+
+// InstalledImplant remembers the item it came from, so it can be given back when it is removed.
+type InstalledImplant struct {
+	Implant
+	ItemName      string
+	IsActive      bool
+	DisabledTurns int
+}
+
+func (i *InstalledImplant) IsDisabled() bool {
+	return i.DisabledTurns > 0
+}
+
+// loadImplants reads the implant catalogue. Implant items refer to it with the "implant" field, eg.
+//
+//	Name: reflex_booster
+//	Description: Wired Reflexes
+//	slot: spine
+//	ability: reflex_boost
+//	energy_cost: 1
+//	install_difficulty: -20
+//	stat_bonus: agility(1)
+//	active_derived_stat_bonus: speed(2)
+func loadImplants(dataRootDir string) map[string]Implant {
+	implantFile := path.Join(dataRootDir, "definitions", "implants.rec")
+	if !fxtools.FileExists(implantFile) {
+		return nil
+	}
+	implants := make(map[string]Implant)
+	for _, record := range recfile.Read(fxtools.MustOpen(implantFile)) {
+		implant := NewImplantFromRecord(record)
+		implants[implant.Name] = implant
+	}
+	return implants
+}
+
+func NewImplantFromRecord(record recfile.Record) Implant {
+	implant := Implant{Slot: ImplantSlotInvalid}
+	for _, field := range record {
+		fieldName := strings.ToLower(field.Name)
+		switch fieldName {
+		case "name":
+			implant.Name = field.Value
+		case "description":
+			implant.Description = field.Value
+		case "slot":
+			implant.Slot = ImplantSlotFromString(field.Value)
+		case "ability":
+			implant.Ability = ImplantAbilityFromString(field.Value)
+		case "energy_cost":
+			implant.EnergyCost = field.AsInt()
+		case "install_difficulty":
+			implant.InstallDifficulty = field.AsInt()
+		case "stat_bonus", "skill_bonus", "derived_stat_bonus":
+			implant.Passive.addBonus(fieldName, field.Value)
+		case "active_stat_bonus", "active_skill_bonus", "active_derived_stat_bonus":
+			implant.ActiveBonus.addBonus(strings.TrimPrefix(fieldName, "active_"), field.Value)
+		}
+	}
+	return implant
+}
+
+func (a *Actor) GetImplantEnergyMax() int {
+	return implantEnergyBase + implantEnergyPerEndurance*a.GetCharSheet().GetStat(special.Endurance)
+}
+
+func (a *Actor) GetImplantEnergy() int {
+	return a.implantEnergy
+}
+
+func (a *Actor) HasImplants() bool {
+	return len(a.implants) > 0
+}
+
+func (a *Actor) GetImplant(slot ImplantSlot) *InstalledImplant {
+	return a.implants[slot]
+}
+
+func (a *Actor) IsImplantSlotFree(slot ImplantSlot) bool {
+	_, isUsed := a.implants[slot]
+	return !isUsed
+}
+
+// GetInstalledImplants returns the implants in slot order.
+func (a *Actor) GetInstalledImplants() []*InstalledImplant {
+	var result []*InstalledImplant
+	for slot := ImplantSlotEyes; slot <= ImplantSlotBrain; slot++ {
+		if implant, isInstalled := a.implants[slot]; isInstalled {
+			result = append(result, implant)
+		}
+	}
+	return result
+}
+
+// installImplant charges the energy pool with the first implant.
+func (a *Actor) installImplant(implant Implant, itemName string) {
+	if len(a.implants) == 0 {
+		a.implantEnergy = a.GetImplantEnergyMax()
+	}
+	a.implants[implant.Slot] = &InstalledImplant{Implant: implant, ItemName: itemName}
+}
+
+func (a *Actor) removeImplant(slot ImplantSlot) *InstalledImplant {
+	implant := a.implants[slot]
+	delete(a.implants, slot)
+	return implant
+}
+
+func (a *Actor) IsImplantAbilityActive(ability ImplantAbility) bool {
+	for _, implant := range a.implants {
+		if implant.Ability == ability && implant.IsActive && !implant.IsDisabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// getImplantEnergyDrain is the energy all active abilities need per turn.
+func (a *Actor) getImplantEnergyDrain() int {
+	drain := 0
+	for _, implant := range a.implants {
+		if implant.IsActive {
+			drain += implant.EnergyCost
+		}
+	}
+	return drain
+}
+
+func (a *Actor) deactivateImplants() {
+	for _, implant := range a.implants {
+		implant.IsActive = false
+	}
+}
+
+// disableImplants is the effect of EMP damage on organic actors with implants.
+func (a *Actor) disableImplants(turns int) {
+	for _, implant := range a.implants {
+		implant.DisabledTurns = max(implant.DisabledTurns, turns)
+		implant.IsActive = false
+	}
+}
+
+// getImplantStatChanges returns the stat changes of all working implants. The name is used as the source of the modifiers.
+func (a *Actor) getImplantStatChanges() []TemporaryStatChange {
+	var result []TemporaryStatChange
+	for _, implant := range a.GetInstalledImplants() {
+		if implant.IsDisabled() {
+			continue
+		}
+		result = append(result, TemporaryStatChange{StatChange: implant.Passive, Name: implant.Description})
+		if implant.IsActive {
+			result = append(result, TemporaryStatChange{StatChange: implant.ActiveBonus, Name: fmt.Sprintf("%s (%s)", implant.Description, implant.Ability.String())})
+		}
+	}
+	return result
+}
+
+func (a *Actor) GetImplantStatModifiers(stat special.Stat) []special.Modifier {
+	var result []special.Modifier
+	for _, change := range a.getImplantStatChanges() {
+		if value, exists := change.StatChanges[stat]; exists {
+			result = append(result, special.DefaultModifier{Source: change.Name, Modifier: value, Order: 1})
+		}
+	}
+	return result
+}
+
+func (a *Actor) GetImplantSkillModifiers(skill special.Skill) []special.Modifier {
+	var result []special.Modifier
+	for _, change := range a.getImplantStatChanges() {
+		if value, exists := change.SkillChanges[skill]; exists {
+			result = append(result, special.DefaultModifier{Source: change.Name, Modifier: value, Order: 1, IsPercent: true})
+		}
+	}
+	return result
+}
+
+func (a *Actor) GetImplantDerivedStatModifiers(stat special.DerivedStat) []special.Modifier {
+	var result []special.Modifier
+	for _, change := range a.getImplantStatChanges() {
+		if value, exists := change.DerivedStatChanges[stat]; exists {
+			result = append(result, special.DefaultModifier{Source: change.Name, Modifier: value, Order: 1})
+		}
 	}
-	return CyberWareInvalid
+	return result
 }
@@ -82,6 +82,8 @@ func NewItemFromRecord(record recfile.Record, icon func(itemCategory foundation.
 			item.effectParameters["damage_interval"] = fxtools.ParseInterval(field.Value)
 		case "effect_radius":
 			item.effectParameters["radius"] = field.AsInt()
+		case "effect_intensity":
+			item.effectParameters["intensity"] = field.AsInt()
 		case "charges":
 			charges = fxtools.ParseInterval(field.Value).Roll()
 		case "stat_bonus":
@@ -114,6 +116,20 @@ func NewItemFromRecord(record recfile.Record, icon func(itemCategory foundation.
 				}
 				item.statChanges.DerivedStatChanges[stat] = bonus
 			}
+		case "duration":
+			item.drugEffect.Duration = field.AsInt()
+		case "crash_duration":
+			item.drugEffect.CrashDuration = field.AsInt()
+		case "addiction_chance":
+			item.drugEffect.AddictionChance = field.AsInt()
+		case "crash_stat_bonus", "crash_skill_bonus", "crash_derived_stat_bonus":
+			item.drugEffect.Crash.addBonus(strings.TrimPrefix(strings.ToLower(field.Name), "crash_"), field.Value)
+		case "withdrawal_stat_bonus", "withdrawal_skill_bonus", "withdrawal_derived_stat_bonus":
+			item.drugEffect.Withdrawal.addBonus(strings.TrimPrefix(strings.ToLower(field.Name), "withdrawal_"), field.Value)
+		case "implant":
+			item.implant = field.Value
+		case "slot":
+			item.equipSlot = foundation.ItemSlotFromString(field.Value)
 		case "equip_flag":
 			item.equipFlag = foundation.ActorFlagFromString(field.Value)
 		case "textfile":
@@ -9,7 +9,7 @@ import (
 	"strings"
 )
 
-func NewActorFromRecord(record recfile.Record, palette textiles.ColorPalette, newItemFromString func(string) foundation.Item) *Actor {
+func NewActorFromRecord(record recfile.Record, palette textiles.ColorPalette, newItemFromString func(string) foundation.Item) (*Actor, error) {
 	actor := NewActor()
 
 	var icon textiles.TextIcon
@@ -25,6 +25,7 @@ func NewActorFromRecord(record recfile.Record, palette textiles.ColorPalette, ne
 	hitpoints := -1
 	actionpoints := -1
 	speed := -1
+	isMechanical := false
 
 	for _, field := range record {
 		switch strings.ToLower(field.Name) {
@@ -77,6 +78,32 @@ func NewActorFromRecord(record recfile.Record, palette textiles.ColorPalette, ne
 			actor.SetDialogueFile(field.Value)
 		case "chatter":
 			actor.SetChatterFile(field.Value)
+		case "behaviour", "behavior":
+			actor.SetBehaviour(field.Value)
+		case "team":
+			actor.SetTeam(field.Value)
+		case "ability":
+			ability, err := NewAbilityFromString(field.Value)
+			if err != nil {
+				return nil, err
+			}
+			actor.AddAbility(ability)
+		case "enemies":
+			for _, enemyTeam := range field.AsList("|") {
+				actor.AddToEnemyTeams(enemyTeam.Value)
+			}
+		case "patrol":
+			actor.SetPatrolRoute(field.Value)
+		case "leash":
+			actor.SetLeashRadius(field.AsInt())
+		case "squad":
+			actor.SetSquad(field.Value)
+		case "morale":
+			actor.SetMaxMorale(field.AsInt())
+		case "body":
+			isMechanical = strings.ToLower(field.Value) == "mechanical"
+		case "alarm":
+			actor.SetAlarmLink(field.Value)
 		case "flags":
 			for _, mFlag := range field.AsList("|") {
 				flags.Set(foundation.ActorFlagFromString(mFlag.Value))
@@ -94,6 +121,10 @@ func NewActorFromRecord(record recfile.Record, palette textiles.ColorPalette, ne
 
 	actor.GetFlags().Init(flags.UnderlyingCopy())
 
+	if isMechanical || actor.IsMechanical() {
+		actor.SetMechanical()
+	}
+
 	if hitpoints != -1 {
 		charSheet.SetDerivedStatAbsoluteValue(special.HitPoints, hitpoints)
 	}
@@ -114,9 +145,13 @@ func NewActorFromRecord(record recfile.Record, palette textiles.ColorPalette, ne
 	}
 
 	actor.SetCharSheet(charSheet)
+	actor.ResetMorale()
 	actor.SetIcon(icon)
 	actor.SetIntrinsicZapEffects(zapEffects)
 	actor.SetIntrinsicUseEffects(useEffects)
+	if err := actor.addIntrinsicAbilities(); err != nil {
+		return nil, err
+	}
 
 	for _, itemName := range equipment {
 		item := newItemFromString(itemName)
@@ -124,5 +159,5 @@ func NewActorFromRecord(record recfile.Record, palette textiles.ColorPalette, ne
 			actor.GetInventory().AddItem(item)
 		}
 	}
-	return actor
+	return actor, nil
 }
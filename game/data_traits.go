@@ -0,0 +1,57 @@
+package game
+
+import (
+	"RogueUI/special"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/recfile"
+	"path"
+	"strings"
+)
+
+// loadTraits reads the traits the player can choose from at character creation.
+// The bonuses use the same syntax as the items, rules change a game rule by a percentage, eg.
+//
+//	Name: Chem Reliant
+//	rule: drug_price(-50)
+//	rule: addiction_chance(100)
+func loadTraits(dataRootDir string) []special.Trait {
+	traitFile := path.Join(dataRootDir, "definitions", "traits.rec")
+	if !fxtools.FileExists(traitFile) {
+		return nil
+	}
+	var traits []special.Trait
+	for _, record := range recfile.Read(fxtools.MustOpen(traitFile)) {
+		traits = append(traits, NewTraitFromRecord(record))
+	}
+	return traits
+}
+
+func NewTraitFromRecord(record recfile.Record) special.Trait {
+	trait := special.NewTrait(record.FindValueForKeyIgnoreCase("name"))
+	for _, field := range record {
+		fieldName := strings.ToLower(field.Name)
+		if fieldName == "description" {
+			trait.Description = field.Value
+			continue
+		}
+		if !fxtools.LooksLikeAFunction(field.Value) {
+			continue
+		}
+		name, args := fxtools.GetNameAndArgs(field.Value)
+		switch fieldName {
+		case "stat_bonus":
+			trait.StatMods[special.StatFromString(name)] = args.GetInt(0)
+		case "derived_stat_bonus":
+			trait.DerivedStatMods[special.DerivedStatFromString(name)] = args.GetInt(0)
+		case "skill_bonus":
+			trait.SkillMods[special.SkillFromString(name)] = args.GetInt(0)
+		case "rule":
+			trait.RuleMods[special.TraitRuleFromString(name)] = args.GetInt(0)
+		}
+	}
+	return trait
+}
+
+func (g *GameState) GetTraitCatalogue() []special.Trait {
+	return g.traitCatalogue
+}
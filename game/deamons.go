@@ -62,10 +62,7 @@ func (g *GameState) removeDeadAndApplyRegeneration() {
 
 	for i := len(g.currentMap().Actors()) - 1; i >= 0; i-- {
 		actor := g.currentMap().Actors()[i]
-		actor.AfterTurn()
-		if actor.HasFlag(foundation.FlagRegenerating) && actor.IsWounded() {
-			actor.Heal(1)
-		}
+		g.applyTurnEffects(actor)
 	}
 	for i := len(g.currentMap().Objects()) - 1; i >= 0; i-- {
 		object := g.currentMap().Objects()[i]
@@ -74,3 +71,13 @@ func (g *GameState) removeDeadAndApplyRegeneration() {
 		}
 	}
 }
+
+// applyTurnEffects is everything that happens to an actor at the end of a turn. Only regenerating actors heal on their own.
+func (g *GameState) applyTurnEffects(actor *Actor) {
+	actor.AfterTurn()
+	g.updateAddictions(actor)
+	g.updateImplants(actor)
+	if actor.HasFlag(foundation.FlagRegenerating) && actor.IsWounded() {
+		actor.Heal(1)
+	}
+}
@@ -0,0 +1,259 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"fmt"
+	"github.com/memmaker/go/fxtools"
+	"math/rand"
+)
+
+const (
+	withdrawalOnsetTurns   = 300
+	addictionRecoveryTurns = 3000
+)
+
+// DrugEffect describes what happens after the timed stat changes of a drug wear off, eg.
+//
+//	duration: 30
+//	crash_stat_bonus: strength(-1)
+//	crash_duration: 20
+//	addiction_chance: 10
+//	withdrawal_stat_bonus: agility(-1)
+type DrugEffect struct {
+	Duration        int
+	Crash           StatChange
+	CrashDuration   int
+	AddictionChance int
+	Withdrawal      StatChange
+}
+
+func (d DrugEffect) HasCrash() bool {
+	return d.CrashDuration > 0
+}
+
+func (d DrugEffect) IsAddictive() bool {
+	return d.AddictionChance > 0
+}
+
+// Addiction starts with withdrawal once the actor has been clean for a while and ends after a long time without the drug.
+type Addiction struct {
+	Drug       string
+	Withdrawal StatChange
+	TurnsClean int
+}
+
+func (a *Addiction) IsInWithdrawal() bool {
+	return a.TurnsClean >= withdrawalOnsetTurns
+}
+
+// addBonus parses a bonus field of an item record, eg. "stat_bonus" with "strength(2)".
+func (s *StatChange) addBonus(bonusType string, value string) {
+	if !fxtools.LooksLikeAFunction(value) {
+		return
+	}
+	name, args := fxtools.GetNameAndArgs(value)
+	bonus := args.GetInt(0)
+	switch bonusType {
+	case "stat_bonus":
+		if s.StatChanges == nil {
+			s.StatChanges = make(map[special.Stat]int)
+		}
+		s.StatChanges[special.StatFromString(name)] = bonus
+	case "skill_bonus":
+		if s.SkillChanges == nil {
+			s.SkillChanges = make(map[special.Skill]int)
+		}
+		s.SkillChanges[special.SkillFromString(name)] = bonus
+	case "derived_stat_bonus":
+		if s.DerivedStatChanges == nil {
+			s.DerivedStatChanges = make(map[special.DerivedStat]int)
+		}
+		s.DerivedStatChanges[special.DerivedStatFromString(name)] = bonus
+	}
+}
+
+// actorConsumeDrug stacks a new dose on top of an active one, which also raises the chance of addiction.
+// Taking another dose ends the crash of the drug.
+func (g *GameState) actorConsumeDrug(actor *Actor, item *GenericItem) {
+	drug := item.drugEffect
+	duration := drug.Duration
+	if duration == 0 {
+		duration = item.charges
+	}
+	doses := 1
+	if activeDose := actor.getTemporaryStatChange(item.Name()); activeDose != nil {
+		doses = activeDose.Doses + 1
+	}
+
+	dose := &TemporaryStatChange{
+		StatChange: item.statChanges,
+		Name:       item.Name(),
+		TurnsLeft:  actor.GetCharSheet().ApplyTraitRule(special.RuleDrugDuration, duration),
+		Doses:      doses,
+	}
+	if drug.HasCrash() {
+		dose.AfterEffect = &TemporaryStatChange{
+			StatChange: drug.Crash,
+			Name:       fmt.Sprintf("%s Crash", item.Name()),
+			TurnsLeft:  drug.CrashDuration,
+			Doses:      doses,
+		}
+	}
+	actor.removeTemporaryStatChange(fmt.Sprintf("%s Crash", item.Name()))
+	actor.AddTemporaryStatChange(dose)
+
+	if g.Player == actor {
+		g.msg(foundation.HiLite("You consume %s", item.Name()))
+		if doses > 1 {
+			g.msg(foundation.HiLite("The effects of %s intensify", item.Name()))
+		}
+	} else {
+		g.msg(foundation.HiLite("%s consumes %s", actor.Name(), item.Name()))
+	}
+
+	g.rollForAddiction(actor, item.Name(), drug, doses)
+
+	g.removeItemFromInventory(actor, item)
+}
+
+func (g *GameState) rollForAddiction(actor *Actor, drugName string, drug DrugEffect, doses int) {
+	if !drug.IsAddictive() || actor.IsAddictedTo(drugName) {
+		return
+	}
+	chance := actor.GetCharSheet().ApplyTraitRule(special.RuleAddictionChance, drug.AddictionChance*doses)
+	if rand.Intn(100) >= chance {
+		return
+	}
+	actor.addictions = append(actor.addictions, &Addiction{Drug: drugName, Withdrawal: drug.Withdrawal})
+	actor.updateAddictionFlags()
+	if actor == g.Player {
+		g.msg(foundation.HiLite("You are addicted to %s", drugName))
+	}
+}
+
+// updateAddictions is called once per turn. Withdrawal only sets in, when no dose of the drug is active.
+func (g *GameState) updateAddictions(actor *Actor) {
+	if len(actor.addictions) == 0 {
+		return
+	}
+	for i := len(actor.addictions) - 1; i >= 0; i-- {
+		addiction := actor.addictions[i]
+		if actor.getTemporaryStatChange(addiction.Drug) != nil {
+			addiction.TurnsClean = 0
+			continue
+		}
+		addiction.TurnsClean++
+		if addiction.TurnsClean == withdrawalOnsetTurns && actor == g.Player {
+			g.msg(foundation.HiLite("You are suffering from %s withdrawal", addiction.Drug))
+		}
+		if addiction.TurnsClean >= addictionRecoveryTurns {
+			actor.addictions = append(actor.addictions[:i], actor.addictions[i+1:]...)
+			if actor == g.Player {
+				g.msg(foundation.HiLite("You are no longer addicted to %s", addiction.Drug))
+			}
+		}
+	}
+	actor.updateAddictionFlags()
+}
+
+func cureAddictions(g *GameState, actor *Actor) []foundation.Animation {
+	if !actor.IsAddicted() {
+		g.msg(foundation.Msg("Nothing happens."))
+		return nil
+	}
+	actor.CureAddictions()
+	g.msg(foundation.Msg("your cravings fade away"))
+	return nil
+}
+
+// doctorCureAddictions is the paid addiction treatment of an NPC doctor.
+func (g *GameState) doctorCureAddictions(doctor *Actor, price int) {
+	if !g.chargePlayer(doctor, price) {
+		g.msg(foundation.Msg("You can't afford the treatment"))
+		return
+	}
+	g.Player.CureAddictions()
+	g.msg(foundation.HiLite("You paid %s caps and your cravings are gone", fmt.Sprint(price)))
+}
+
+func (a *Actor) IsAddicted() bool {
+	return len(a.addictions) > 0
+}
+
+func (a *Actor) IsAddictedTo(drugName string) bool {
+	for _, addiction := range a.addictions {
+		if addiction.Drug == drugName {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Actor) GetAddictions() []*Addiction {
+	return a.addictions
+}
+
+func (a *Actor) CureAddictions() {
+	a.addictions = nil
+	a.updateAddictionFlags()
+}
+
+func (a *Actor) updateAddictionFlags() {
+	a.statusFlags.Unset(foundation.FlagAddicted)
+	a.statusFlags.Unset(foundation.FlagWithdrawal)
+	for _, addiction := range a.addictions {
+		a.statusFlags.Set(foundation.FlagAddicted)
+		if addiction.IsInWithdrawal() {
+			a.statusFlags.Set(foundation.FlagWithdrawal)
+		}
+	}
+}
+
+func (a *Actor) getTemporaryStatChange(name string) *TemporaryStatChange {
+	for _, statChange := range a.temporaryStatChanges {
+		if statChange.Name == name {
+			return statChange
+		}
+	}
+	return nil
+}
+
+func (a *Actor) removeTemporaryStatChange(name string) {
+	for i, statChange := range a.temporaryStatChanges {
+		if statChange.Name == name {
+			a.temporaryStatChanges = append(a.temporaryStatChanges[:i], a.temporaryStatChanges[i+1:]...)
+			return
+		}
+	}
+}
+
+func (a *Actor) getWithdrawalStatModifiers(stat special.Stat) []special.Modifier {
+	var result []special.Modifier
+	for _, addiction := range a.addictions {
+		if value, exists := addiction.Withdrawal.StatChanges[stat]; exists && addiction.IsInWithdrawal() {
+			result = append(result, special.DefaultModifier{Source: addiction.Drug + " Withdrawal", Modifier: value, Order: 1})
+		}
+	}
+	return result
+}
+
+func (a *Actor) getWithdrawalSkillModifiers(skill special.Skill) []special.Modifier {
+	var result []special.Modifier
+	for _, addiction := range a.addictions {
+		if value, exists := addiction.Withdrawal.SkillChanges[skill]; exists && addiction.IsInWithdrawal() {
+			result = append(result, special.DefaultModifier{Source: addiction.Drug + " Withdrawal", Modifier: value, Order: 1, IsPercent: true})
+		}
+	}
+	return result
+}
+
+func (a *Actor) getWithdrawalDerivedStatModifiers(stat special.DerivedStat) []special.Modifier {
+	var result []special.Modifier
+	for _, addiction := range a.addictions {
+		if value, exists := addiction.Withdrawal.DerivedStatChanges[stat]; exists && addiction.IsInWithdrawal() {
+			result = append(result, special.DefaultModifier{Source: addiction.Drug + " Withdrawal", Modifier: value, Order: 1})
+		}
+	}
+	return result
+}
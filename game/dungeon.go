@@ -47,6 +47,7 @@ func (g *GameState) GotoNamedLevel(levelName string, location string) {
 	}
 
 	if g.currentMap() != nil && g.Player != nil { // RemoveItem Player from Old Map
+		g.leaveMap(g.currentMapName)
 		g.currentMap().RemoveActor(g.Player)
 		g.Player.RemoveLevelStatusEffects()
 	}
@@ -63,6 +64,8 @@ func (g *GameState) GotoNamedLevel(levelName string, location string) {
 
 	if firstTimeInit != nil {
 		firstTimeInit()
+	} else {
+		g.catchUpMap(loadedMap)
 	}
 
 	g.updateUIStatus()
@@ -35,6 +35,7 @@ func GetAllUseEffects() map[string]func(g *GameState, user *Actor) (bool, []foun
 		"raise_level":                    endTurn(true, noAnim(raiseLevel)),
 		"uncloak":                        endTurn(true, uncloak),
 		"satiate_fully":                  endTurn(true, satiateFully),
+		"cure_addiction":                 endTurn(true, cureAddictions),
 	}
 }
 
@@ -373,7 +374,9 @@ func teleportWithAnimation(g *GameState, actor *Actor, targetPos geometry.Point)
 	}
 	g.actorMove(actor, targetPos)
 
-	g.afterPlayerMoved(origin, false)
+	if actor == g.Player {
+		g.afterPlayerMoved(origin, false)
+	}
 
 	vanishAnim, _ := g.ui.GetAnimTeleport(actor, origin, targetPos, nil)
 
@@ -552,6 +555,7 @@ func (g *GameState) unblindPlayer() {
 	player := g.Player
 	flags := player.GetFlags()
 	flags.Unset(foundation.FlagBlind)
+	flags.Unset(foundation.FlagDazzled)
 	g.msg(foundation.Msg("You can see again"))
 }
 
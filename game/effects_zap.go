@@ -12,24 +12,28 @@ import (
 
 func GetAllZapEffects() map[string]func(g *GameState, zapper *Actor, aimPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var zapEffects = map[string]func(g *GameState, zapper *Actor, aimPos geometry.Point, params foundation.Params) []foundation.Animation{
-		"fire_breath":    fireBreath,
-		"explode":        explosion,
-		"plasma_explode": plasmaExplosion,
-		//"magic_missile":        magicMissile,
-		//"haste_target":         hasteTarget,
-		//"slow_target":          slowTarget,
-		//"teleport_target_away": teleportTargetAway,
-		//"teleport_target_to":   teleportTargetTo,
-		//"cancel_target":        cancelTarget,
-		//"invisibility_target":  invisibilityTarget,
-		//"cold_ray":             coldRay,
-		//"lightning_ray":        lightningRay,
-		//"fire_ray":             fireRay,
-		//"charge_attack":        chargeAttack,
-		//"heroic_charge":        heroicCharge,
-		//"magic_dart":           magicDart,
-		//"magic_arrow":          magicArrow,
-		//"hold_target":          holdTarget,
+		"fire_breath":          fireBreath,
+		"explode":              explosion,
+		"plasma_explode":       plasmaExplosion,
+		"smoke_cloud":          smokeCloud,
+		"gas_cloud":            gasCloud,
+		"laser_burst":          laserBurst,
+		"magic_missile":        magicMissile,
+		"haste_target":         hasteTarget,
+		"slow_target":          slowTarget,
+		"teleport_target_away": teleportTargetAway,
+		"teleport_target_to":   teleportTargetTo,
+		"cancel_target":        cancelTarget,
+		"invisibility_target":  invisibilityTarget,
+		"cold_ray":             coldRay,
+		"lightning_ray":        lightningRay,
+		"fire_ray":             fireRay,
+		"charge_attack":        chargeAttack,
+		"heroic_charge":        heroicCharge,
+		"uncloak_and_charge":   uncloakAndCharge,
+		"magic_dart":           magicDart,
+		"magic_arrow":          magicArrow,
+		"hold_target":          holdTarget,
 	}
 	return zapEffects
 }
@@ -51,15 +55,15 @@ func forceDescendTarget(g *GameState, zapper *Actor, pos geometry.Point) []found
 
 */
 
-func magicDart(g *GameState, zapper *Actor, pos geometry.Point) []foundation.Animation {
-	return magicItemProjectile(g, zapper, pos, "dart", "a dart")
+func magicDart(g *GameState, zapper *Actor, pos geometry.Point, params foundation.Params) []foundation.Animation {
+	return magicItemProjectile(g, zapper, pos, "dart", "a dart", params)
 }
 
-func magicArrow(g *GameState, zapper *Actor, pos geometry.Point) []foundation.Animation {
-	return magicItemProjectile(g, zapper, pos, "arrow", "an arrow")
+func magicArrow(g *GameState, zapper *Actor, pos geometry.Point, params foundation.Params) []foundation.Animation {
+	return magicItemProjectile(g, zapper, pos, "arrow", "an arrow", params)
 }
 
-func uncloakAndCharge(g *GameState, zapper *Actor, pos geometry.Point) []foundation.Animation {
+func uncloakAndCharge(g *GameState, zapper *Actor, pos geometry.Point, params foundation.Params) []foundation.Animation {
 	zapper.GetFlags().Unset(foundation.FlagInvisible)
 	zapper.SetAware()
 	uncloakAnim, _ := g.ui.GetAnimUncloakAtPosition(zapper, zapper.Position())
@@ -69,11 +73,11 @@ func uncloakAndCharge(g *GameState, zapper *Actor, pos geometry.Point) []foundat
 
 	return []foundation.Animation{uncloakAnim}
 }
-func chargeAttack(g *GameState, zapper *Actor, pos geometry.Point) []foundation.Animation {
+func chargeAttack(g *GameState, zapper *Actor, pos geometry.Point, params foundation.Params) []foundation.Animation {
 	moveAnim, _ := charge(g, zapper, pos, false, g.getLineOfSight)
 	return []foundation.Animation{moveAnim}
 }
-func heroicCharge(g *GameState, zapper *Actor, pos geometry.Point) []foundation.Animation {
+func heroicCharge(g *GameState, zapper *Actor, pos geometry.Point, params foundation.Params) []foundation.Animation {
 	moveAnim, _ := charge(g, zapper, pos, true, g.getLineOfSight)
 	return []foundation.Animation{moveAnim}
 }
@@ -100,8 +104,8 @@ func charge(g *GameState, zapper *Actor, pos geometry.Point, isHeroic bool, getP
 	return moveAnim, targetPos
 }
 
-func coldRay(g *GameState, zapper *Actor, aimPos geometry.Point) []foundation.Animation {
-	damage := 8
+func coldRay(g *GameState, zapper *Actor, aimPos geometry.Point, params foundation.Params) []foundation.Animation {
+	damage := params.GetDamageOrDefault(8)
 	trailLead := '☼'
 	trailColors := []string{"White", "White", "LightCyan", "light_blue_3", "Blue"}
 	hitEntityHandler := func(hitPos geometry.Point) []foundation.Animation {
@@ -141,6 +145,8 @@ func plasmaExplosion(g *GameState, zapper *Actor, loc geometry.Point, params fou
 	radius += bonusRadius
 	damageAmount := params.GetDamageOrDefault(35)
 
+	g.makeNoise(loc, noiseRadiusExplosion, zapper)
+
 	affected := g.currentMap().GetDijkstraMap(loc, radius, func(p geometry.Point) bool {
 		return g.currentMap().IsTileWalkable(p) || g.currentMap().IsTileWithFlagAt(p, gridmap.TileFlagDestroyable)
 	})
@@ -171,12 +177,57 @@ func plasmaExplosion(g *GameState, zapper *Actor, loc geometry.Point, params fou
 
 	return animationsForThisFrame
 }
+func smokeCloud(g *GameState, zapper *Actor, loc geometry.Point, params foundation.Params) []foundation.Animation {
+	radius := params.GetIntOrDefault("radius", 3)
+	intensity := params.GetIntOrDefault("intensity", 8)
+
+	affected := g.spreadField(loc, gridmap.FieldSmoke, radius, intensity)
+
+	smokeColor := fxtools.HDRColor{R: 0.4, G: 0.4, B: 0.4, A: 1}
+	cloudAnim := g.ui.GetAnimRadialExplosion(affected, smokeColor, nil)
+
+	return OneAnimation(cloudAnim)
+}
+
+func gasCloud(g *GameState, zapper *Actor, loc geometry.Point, params foundation.Params) []foundation.Animation {
+	radius := params.GetIntOrDefault("radius", 2)
+	intensity := params.GetIntOrDefault("intensity", 6)
+
+	affected := g.spreadField(loc, gridmap.FieldGas, radius, intensity)
+
+	gasColor := fxtools.HDRColor{R: 0.3, G: 0.8, B: 0.2, A: 1}
+	cloudAnim := g.ui.GetAnimRadialExplosion(affected, gasColor, nil)
+
+	return OneAnimation(cloudAnim)
+}
+
+func laserBurst(g *GameState, zapper *Actor, loc geometry.Point, params foundation.Params) []foundation.Animation {
+	damage := SourcedDamage{
+		NameOfThing:     "laser",
+		Attacker:        zapper,
+		IsObviousAttack: true,
+		TargetingMode:   special.TargetingModeFireSingle,
+		DamageType:      special.DamageTypeLaser,
+		DamageAmount:    params.GetDamageOrDefault(15),
+	}
+	damageAnims := g.damageLocation(damage, loc)
+
+	laserAnim := g.ui.GetAnimLaser([]geometry.Point{loc}, fxtools.NewColorFromRGBA(g.palette.Get("red_8")).MultiplyWithScalar(2), nil)
+	if laserAnim == nil {
+		return damageAnims
+	}
+	laserAnim.SetFollowUp(damageAnims)
+	return OneAnimation(laserAnim)
+}
+
 func explosion(g *GameState, zapper *Actor, loc geometry.Point, params foundation.Params) []foundation.Animation {
 	radius := params.GetIntOrDefault("radius", 3)
 	bonusRadius := params.GetIntOrDefault("bonus_radius", 0)
 	radius += bonusRadius
 	damageAmount := params.GetDamageOrDefault(35)
 
+	g.makeNoise(loc, noiseRadiusExplosion, zapper)
+
 	affected := g.currentMap().GetDijkstraMap(loc, radius, func(p geometry.Point) bool {
 		return g.currentMap().IsTileWalkable(p) || g.currentMap().IsTileWithFlagAt(p, gridmap.TileFlagDestroyable)
 	})
@@ -234,9 +285,9 @@ func (g *GameState) singleRay(origin, target geometry.Point, leadIcon rune, trai
 	return []foundation.Animation{projAnim}
 }
 
-func fireRay(g *GameState, zapper *Actor, aimPos geometry.Point) []foundation.Animation {
+func fireRay(g *GameState, zapper *Actor, aimPos geometry.Point, params foundation.Params) []foundation.Animation {
 
-	damageRolled := 10
+	damageRolled := params.GetDamageOrDefault(10)
 
 	trailColors := []string{"White", "Yellow", "LightRed", "Red"}
 
@@ -258,8 +309,8 @@ func fireRay(g *GameState, zapper *Actor, aimPos geometry.Point) []foundation.An
 	return g.singleRay(zapper.Position(), aimPos, ' ', trailColors, hitEntityHandler)
 }
 
-func lightningRay(g *GameState, zapper *Actor, aimPos geometry.Point) []foundation.Animation {
-	damageRolled := 7
+func lightningRay(g *GameState, zapper *Actor, aimPos geometry.Point, params foundation.Params) []foundation.Animation {
+	damageRolled := params.GetDamageOrDefault(7)
 
 	trailColors := []string{
 		"White",
@@ -320,7 +371,7 @@ func (g *GameState) nearbyActors(curPos geometry.Point, exclude map[*Actor]bool)
 		return true
 	})
 }
-func invisibilityTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func invisibilityTarget(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var animations []foundation.Animation
 
 	origin := zapper.Position()
@@ -342,7 +393,7 @@ func invisibilityTarget(g *GameState, zapper *Actor, targetPos geometry.Point) [
 	return animations
 }
 
-func teleportTargetTo(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func teleportTargetTo(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var animations []foundation.Animation
 
 	origin := zapper.Position()
@@ -374,7 +425,7 @@ func teleportTargetTo(g *GameState, zapper *Actor, targetPos geometry.Point) []f
 	return animations
 }
 
-func teleportTargetAway(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func teleportTargetAway(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var animations []foundation.Animation
 	origin := originFromZapperOrWall(g, zapper, targetPos)
 
@@ -415,7 +466,7 @@ func originFromZapperOrWall(g *GameState, zapper *Actor, targetPos geometry.Poin
 	return origin
 }
 
-func cancelTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func cancelTarget(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var animations []foundation.Animation
 
 	origin := zapper.Position()
@@ -433,7 +484,7 @@ func cancelTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []found
 
 	return animations
 }
-func holdTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func holdTarget(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var animations []foundation.Animation
 
 	origin := originFromZapperOrWall(g, zapper, targetPos)
@@ -447,12 +498,15 @@ func holdTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []foundat
 
 	if g.currentMap().IsActorAt(targetPos) {
 		targetActor := g.currentMap().ActorAt(targetPos)
-		targetActor.GetFlags().Increase(foundation.FlagHeld, rand.Intn(10)+5)
+		targetActor.GetFlags().Increase(foundation.FlagHeld, params.GetIntOrDefault("duration", rand.Intn(10)+5))
+		if g.canPlayerSee(targetPos) {
+			g.msg(foundation.HiLite("%s is held in place", targetActor.Name()))
+		}
 	}
 
 	return animations
 }
-func slowTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func slowTarget(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var animations []foundation.Animation
 
 	origin := originFromZapperOrWall(g, zapper, targetPos)
@@ -472,7 +526,7 @@ func slowTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []foundat
 	return animations
 }
 
-func hasteTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func hasteTarget(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	var animations []foundation.Animation
 
 	origin := zapper.Position()
@@ -491,7 +545,7 @@ func hasteTarget(g *GameState, zapper *Actor, targetPos geometry.Point) []founda
 	return animations
 }
 
-func magicMissile(g *GameState, zapper *Actor, targetPos geometry.Point) []foundation.Animation {
+func magicMissile(g *GameState, zapper *Actor, targetPos geometry.Point, params foundation.Params) []foundation.Animation {
 	origin := zapper.Position()
 	pathOfFlight := g.getLineOfSight(origin, targetPos)
 
@@ -509,7 +563,7 @@ func magicMissile(g *GameState, zapper *Actor, targetPos geometry.Point) []found
 		IsObviousAttack: false,
 		TargetingMode:   special.TargetingModeFireSingle,
 		DamageType:      special.DamageTypeRadiation,
-		DamageAmount:    5,
+		DamageAmount:    params.GetDamageOrDefault(5),
 	}
 	damageConsequences := g.damageLocation(damage, targetPos)
 	onHitAnimations = append(onHitAnimations, damageConsequences...)
@@ -521,7 +575,7 @@ func magicMissile(g *GameState, zapper *Actor, targetPos geometry.Point) []found
 	return OneAnimation(projAnim)
 }
 
-func magicItemProjectile(g *GameState, zapper *Actor, targetPos geometry.Point, itemName, friendlyName string) []foundation.Animation {
+func magicItemProjectile(g *GameState, zapper *Actor, targetPos geometry.Point, itemName, friendlyName string, params foundation.Params) []foundation.Animation {
 	origin := originFromZapperOrWall(g, zapper, targetPos)
 	sourceName := nameOfDamageSource(zapper, friendlyName)
 	pathOfFlight := g.getLineOfSight(origin, targetPos)
@@ -544,7 +598,7 @@ func magicItemProjectile(g *GameState, zapper *Actor, targetPos geometry.Point,
 		IsObviousAttack: true,
 		TargetingMode:   special.TargetingModeFireSingle,
 		DamageType:      special.DamageTypePlasma,
-		DamageAmount:    dart.GetThrowDamage().Roll(),
+		DamageAmount:    params.GetDamageOrDefault(dart.GetThrowDamage().Roll()),
 	}
 	damageConsequences := g.damageLocation(damage, targetPos)
 
@@ -567,6 +621,7 @@ func nameOfDamageSource(zapper *Actor, otherName string) string {
 func (g *GameState) damageLocation(damage SourcedDamage, targetPos geometry.Point) []foundation.Animation {
 	if damage.DamageType == special.DamageTypeExplosive || damage.DamageType == special.DamageTypeFire {
 		g.makeMapBurned(targetPos)
+		g.igniteLocation(targetPos, damage.DamageType)
 	}
 
 	if g.currentMap().IsActorAt(targetPos) {
@@ -597,6 +652,21 @@ type SourcedDamage struct {
 	DamageAmount    int
 	BodyPart        special.BodyPart
 	DamagePerBullet []int
+	// IsCritical is decided by the hit roll, the severity is rolled when the damage is applied
+	IsCritical bool
+}
+
+// Multiplied is the damage of a critical hit.
+func (d SourcedDamage) Multiplied(percent int) SourcedDamage {
+	d.DamageAmount = d.DamageAmount * percent / 100
+	if len(d.DamagePerBullet) > 0 {
+		perBullet := make([]int, len(d.DamagePerBullet))
+		for i, bulletDamage := range d.DamagePerBullet {
+			perBullet[i] = bulletDamage * percent / 100
+		}
+		d.DamagePerBullet = perBullet
+	}
+	return d
 }
 
 func (d SourcedDamage) IsActor() bool {
@@ -615,11 +685,30 @@ func (g *GameState) damageActorWithFollowUp(
 	done func(),
 	followUps []foundation.Animation,
 ) []foundation.Animation {
+	if victim.IsImmuneTo(damage.DamageType) {
+		if damage.IsObviousAttack {
+			g.trySetHostile(victim, damage.Attacker)
+		}
+		if done != nil {
+			done()
+		}
+		return followUps
+	}
 
 	didCripple := victim.TakeDamage(damage)
+	if didCripple {
+		g.onBodyPartCrippled(victim, victim.mainBodyPart(damage.BodyPart))
+	}
+
+	if damage.DamageType == special.DamageTypeEMP && victim.IsMechanical() && victim.IsAlive() {
+		g.empDisable(victim, damage.DamageAmount)
+	} else if damage.DamageType == special.DamageTypeEMP && victim.HasImplants() && victim.IsAlive() {
+		g.empDisableImplants(victim, damage.DamageAmount)
+	}
 
 	if damage.IsObviousAttack {
 		g.trySetHostile(victim, damage.Attacker)
+		g.alertAllies(victim, damage.Attacker)
 	}
 	isKill := victim.GetHitPoints() <= 0
 	isOverKill := victim.GetHitPoints() <= (-victim.GetHitPointsMax() / 2)
@@ -644,11 +733,14 @@ func (g *GameState) damageActorWithFollowUp(
 			damageAudioCue = victim.GetDeathAudioCue()
 		}
 		// TODO: replace this with cool matching death animations
-		g.makeMapBloody(victim.Position())
+		if !victim.IsMechanical() {
+			g.makeMapBloody(victim.Position())
+		}
 		damageAnim = g.ui.GetAnimDamage(g.spreadBloodAround, victim.Position(), damage.DamageAmount, 4, done)
 		damageAnim.SetFollowUp(followUps)
 	} else { // only a flesh wound
 		damageAudioCue = victim.GetHitAudioCue(damage.TargetingMode.IsMelee())
+		g.onMoraleDamage(victim, damage, didCripple)
 
 		//
 		bullets := 1
@@ -673,10 +765,16 @@ func (g *GameState) trySetHostile(affected *Actor, sourceOfTrouble *Actor) {
 	}
 	if affected.IsAlive() &&
 		!affected.IsPanicking() &&
+		!affected.IsSurrendered() &&
 		!affected.IsHostileTowards(sourceOfTrouble) &&
 		g.canActorSee(affected, sourceOfTrouble.Position()) {
+		affected.SetFacing(directionTowards(affected.Position(), sourceOfTrouble.Position()))
 		affected.SetHostileTowards(sourceOfTrouble)
-		affected.SetGoal(GoalKillActor(affected, sourceOfTrouble))
+		affected.ChangeAlertLevel(alertLevelMax)
+		affected.Investigate(sourceOfTrouble.Position())
+		fightBack := GoalKillActor(affected, sourceOfTrouble)
+		fightBack.IsReaction = true
+		affected.SetGoal(fightBack)
 		if sourceOfTrouble == g.Player {
 			g.ui.UpdateVisibleActors()
 		}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/gridmap"
+	"RogueUI/special"
+	"github.com/memmaker/go/geometry"
+)
+
+func (g *GameState) isFlammableAt(p geometry.Point) bool {
+	if g.currentMap().IsTileWithFlagAt(p, gridmap.TileFlagFlammable) {
+		return true
+	}
+	if objectAt, ok := g.currentMap().TryGetObjectAt(p); ok && objectAt.IsFlammable() {
+		return true
+	}
+	return false
+}
+
+// updateEnvironmentalFields lets fire, smoke and gas spread and decay
+// and applies their effects to everything standing in them.
+func (g *GameState) updateEnvironmentalFields() {
+	currentMap := g.currentMap()
+	if !currentMap.HasFields() {
+		return
+	}
+
+	ignited := currentMap.UpdateFields(g.isFlammableAt)
+	for _, pos := range ignited {
+		g.makeMapBurned(pos)
+	}
+
+	var animations []foundation.Animation
+	var visibleFire []geometry.Point
+	for _, pos := range currentMap.GetAllFieldPositions() {
+		fields := currentMap.FieldsAt(pos)
+		if fields.Fire > 0 {
+			animations = append(animations, g.burnLocation(pos, fields.Fire)...)
+			if g.canPlayerSee(pos) {
+				visibleFire = append(visibleFire, pos)
+			}
+		}
+		if fields.Gas > 0 && currentMap.IsActorAt(pos) {
+			animations = append(animations, g.gasActor(currentMap.ActorAt(pos), fields.Gas)...)
+		}
+	}
+
+	if len(visibleFire) > 0 {
+		animations = append(animations, g.ui.GetAnimFire(visibleFire, nil))
+	}
+
+	g.ui.AddAnimations(animations)
+}
+
+// igniteLocation leaves flames and smoke behind after fire or explosive damage.
+// Flammable tiles and objects catch fire, everything else only burns briefly.
+func (g *GameState) igniteLocation(pos geometry.Point, damageType special.DamageType) {
+	isFlammable := g.isFlammableAt(pos)
+	switch damageType {
+	case special.DamageTypeFire:
+		if isFlammable {
+			g.currentMap().AddField(pos, gridmap.FieldFire, 6)
+		} else {
+			g.currentMap().AddField(pos, gridmap.FieldFire, 2)
+		}
+	case special.DamageTypeExplosive:
+		if isFlammable {
+			g.currentMap().AddField(pos, gridmap.FieldFire, 4)
+		}
+		g.currentMap().AddField(pos, gridmap.FieldSmoke, 3)
+	}
+}
+
+func (g *GameState) burnLocation(pos geometry.Point, intensity int) []foundation.Animation {
+	damage := SourcedDamage{
+		NameOfThing:     "fire",
+		IsObviousAttack: false,
+		TargetingMode:   special.TargetingModeFireSingle,
+		DamageType:      special.DamageTypeFire,
+		DamageAmount:    intensity/2 + 1,
+	}
+	if g.currentMap().IsActorAt(pos) {
+		victim := g.currentMap().ActorAt(pos)
+		if victim == g.Player {
+			g.msg(foundation.Msg("You are burning!"))
+		}
+		return g.damageActor(damage, victim)
+	}
+	if objectAt, ok := g.currentMap().TryGetObjectAt(pos); ok {
+		return objectAt.OnDamage(damage)
+	}
+	return nil
+}
+
+func (g *GameState) gasActor(victim *Actor, intensity int) []foundation.Animation {
+	if !victim.IsAlive() || victim.HasFlag(foundation.FlagGasProtection) || victim.IsMechanical() {
+		return nil
+	}
+	resistance := min(100, max(0, victim.GetCharSheet().GetDerivedStat(special.PoisonResistance)))
+	damageAmount := (intensity/3 + 1) * (100 - resistance) / 100
+	if damageAmount <= 0 {
+		return nil
+	}
+	if victim == g.Player {
+		g.msg(foundation.Msg("You are choking on the gas!"))
+	}
+	damage := SourcedDamage{
+		NameOfThing:     "gas",
+		IsObviousAttack: false,
+		TargetingMode:   special.TargetingModeFireSingle,
+		DamageType:      special.DamageTypePoison,
+		DamageAmount:    damageAmount,
+	}
+	return g.damageActor(damage, victim)
+}
+
+// spreadField fills the area around the origin with the given field.
+// The intensity falls off with the distance to the origin.
+func (g *GameState) spreadField(origin geometry.Point, fieldType gridmap.FieldType, radius int, intensity int) map[geometry.Point]int {
+	affected := g.currentMap().GetDijkstraMap(origin, radius, func(p geometry.Point) bool {
+		return g.currentMap().IsTileWalkable(p)
+	})
+	for pos, cost := range affected {
+		g.currentMap().AddField(pos, fieldType, max(1, intensity-cost/10))
+	}
+	return affected
+}
@@ -0,0 +1,290 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"fmt"
+)
+
+// updateImplants is called once per turn. Active abilities drain the energy, which only recharges while all abilities are off.
+func (g *GameState) updateImplants(actor *Actor) {
+	if !actor.HasImplants() {
+		return
+	}
+	for _, implant := range actor.implants {
+		if implant.DisabledTurns > 0 {
+			implant.DisabledTurns--
+			if implant.DisabledTurns == 0 && actor == g.Player {
+				g.msg(foundation.HiLite("Your %s is working again", implant.Description))
+			}
+		}
+	}
+
+	drain := actor.getImplantEnergyDrain()
+	if drain == 0 {
+		if g.TurnsTaken()%implantRechargeInterval == 0 {
+			actor.implantEnergy = min(actor.GetImplantEnergyMax(), actor.implantEnergy+1)
+		}
+		return
+	}
+	if actor.implantEnergy < drain {
+		actor.deactivateImplants()
+		if actor == g.Player {
+			g.msg(foundation.Msg("Your implants run out of energy"))
+			g.afterImplantsChanged()
+		}
+		return
+	}
+	actor.implantEnergy -= drain
+}
+
+// empDisableImplants shuts down the implants of organic actors. Machines are handled by empDisable.
+func (g *GameState) empDisableImplants(victim *Actor, damageAmount int) {
+	turns := (3 + damageAmount/5) * implantEMPDurationMultiplier
+	victim.disableImplants(turns)
+	if victim == g.Player {
+		g.msg(foundation.Msg("Your implants short out"))
+		g.afterImplantsChanged()
+	} else if g.couldPlayerSeeActor(victim) {
+		g.msg(foundation.HiLite("The implants of %s short out", victim.Name()))
+	}
+}
+
+func (g *GameState) playerToggleImplant(implant *InstalledImplant) {
+	if implant.IsActive {
+		implant.IsActive = false
+		g.msg(foundation.HiLite("You switch off your %s", implant.Ability.String()))
+		g.afterImplantsChanged()
+		return
+	}
+	if implant.IsDisabled() {
+		g.msg(foundation.HiLite("Your %s doesn't respond", implant.Description))
+		return
+	}
+	if g.Player.implantEnergy < implant.EnergyCost {
+		g.msg(foundation.Msg("You don't have enough energy left"))
+		return
+	}
+	implant.IsActive = true
+	g.msg(foundation.HiLite("You switch on your %s", implant.Ability.String()))
+	g.afterImplantsChanged()
+}
+
+// afterImplantsChanged updates everything that depends on the active implants of the player.
+func (g *GameState) afterImplantsChanged() {
+	g.updatePlayerLightSource()
+	g.updatePlayerFoVAndApplyExploration()
+	g.updateUIStatus()
+}
+
+// getImplantItems returns the implant items in the inventory of the player.
+func (g *GameState) getImplantItems() []*GenericItem {
+	var result []*GenericItem
+	for _, item := range g.Player.GetInventory().Items() {
+		if genericItem, isGeneric := item.(*GenericItem); isGeneric && genericItem.IsImplant() {
+			result = append(result, genericItem)
+		}
+	}
+	return result
+}
+
+func (g *GameState) getImplantForItem(item *GenericItem) (Implant, bool) {
+	implant, exists := g.implantCatalogue[item.implant]
+	return implant, exists && implant.Slot != ImplantSlotInvalid
+}
+
+// implantSurgeryRoll uses the weaker of the two skills needed for the surgery.
+func (g *GameState) implantSurgeryRoll(difficulty int) special.CheckResult {
+	charSheet := g.Player.GetCharSheet()
+	skill := special.Biology
+	if charSheet.GetSkill(special.Technology) < charSheet.GetSkill(special.Biology) {
+		skill = special.Technology
+	}
+	return charSheet.SkillRoll(skill, difficulty)
+}
+
+// payForSurgery uses a charge of the doctor's bag, which is needed for every self-surgery.
+func (g *GameState) payForSurgery() bool {
+	bag := g.Player.GetInventory().GetItemByName(doctorsBagItemName)
+	if bag == nil {
+		g.msg(foundation.Msg("You need a doctor's bag to operate on yourself"))
+		return false
+	}
+	return g.hasPaidWithCharge(g.Player, bag)
+}
+
+// botchedSurgery hurts the player. A critical failure also cripples the body part of the slot.
+func (g *GameState) botchedSurgery(slot ImplantSlot, isCritical bool) {
+	part := slot.BodyPart()
+	damage := SourcedDamage{
+		NameOfThing:     "surgery",
+		IsObviousAttack: false,
+		TargetingMode:   special.TargetingModeFireSingle,
+		DamageType:      special.DamageTypeNormal,
+		DamageAmount:    implantSurgeryDamage,
+		BodyPart:        part,
+	}
+	g.ui.AddAnimations(g.damageActor(damage, g.Player))
+	if isCritical && g.Player.crippleBodyPart(part) {
+		g.onBodyPartCrippled(g.Player, part)
+	}
+}
+
+// playerInstallImplant is the self-surgery. With a critical failure the implant is ruined and the body part crippled.
+func (g *GameState) playerInstallImplant(item *GenericItem) {
+	implant, isValid := g.getImplantForItem(item)
+	if !isValid {
+		return
+	}
+	if !g.Player.IsImplantSlotFree(implant.Slot) {
+		g.msg(foundation.HiLite("You already have an implant in your %s", implant.Slot.String()))
+		return
+	}
+	if !g.payForSurgery() {
+		return
+	}
+
+	result := g.implantSurgeryRoll(implant.InstallDifficulty)
+	switch {
+	case result.Success:
+		g.removeItemFromInventory(g.Player, item)
+		g.Player.installImplant(implant, item.InternalName())
+		g.msg(foundation.HiLite("You install %s", implant.Description))
+	case result.Crit:
+		g.removeItemFromInventory(g.Player, item)
+		g.msg(foundation.HiLite("You botch the installation and ruin %s", implant.Description))
+		g.botchedSurgery(implant.Slot, true)
+	default:
+		g.msg(foundation.HiLite("You fail to install %s and hurt yourself", implant.Description))
+		g.botchedSurgery(implant.Slot, false)
+	}
+	g.updateUIStatus()
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+func (g *GameState) playerRemoveImplant(slot ImplantSlot) {
+	implant := g.Player.GetImplant(slot)
+	if implant == nil || !g.payForSurgery() {
+		return
+	}
+
+	result := g.implantSurgeryRoll(implant.InstallDifficulty)
+	switch {
+	case result.Success:
+		g.Player.removeImplant(slot)
+		g.Player.GetInventory().AddItem(g.NewItemFromString(implant.ItemName))
+		g.msg(foundation.HiLite("You remove %s", implant.Description))
+	case result.Crit:
+		g.Player.removeImplant(slot)
+		g.msg(foundation.HiLite("You botch the removal and ruin %s", implant.Description))
+		g.botchedSurgery(slot, true)
+	default:
+		g.msg(foundation.HiLite("You fail to remove %s and hurt yourself", implant.Description))
+		g.botchedSurgery(slot, false)
+	}
+	g.afterImplantsChanged()
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+// appendImplantMenuItems adds the ability toggles and the self-surgery to the skill menu.
+func (g *GameState) appendImplantMenuItems(menuItems []foundation.MenuItem) []foundation.MenuItem {
+	for _, installed := range g.Player.GetInstalledImplants() {
+		implant := installed
+		if implant.HasAbility() {
+			state := "off"
+			if implant.IsActive {
+				state = "on"
+			}
+			menuItems = append(menuItems, foundation.MenuItem{
+				Name: fmt.Sprintf("%s: %s (%d/%d energy)", implant.Ability.String(), state, g.Player.GetImplantEnergy(), g.Player.GetImplantEnergyMax()),
+				Action: func() {
+					g.playerToggleImplant(implant)
+				},
+				CloseMenus: true,
+			})
+		}
+		menuItems = append(menuItems, foundation.MenuItem{
+			Name: fmt.Sprintf("Remove %s (%s)", implant.Description, implant.Slot.String()),
+			Action: func() {
+				g.playerRemoveImplant(implant.Slot)
+			},
+			CloseMenus: true,
+		})
+	}
+	for _, implantItem := range g.getImplantItems() {
+		item := implantItem
+		implant, isValid := g.getImplantForItem(item)
+		if !isValid || !g.Player.IsImplantSlotFree(implant.Slot) {
+			continue
+		}
+		menuItems = append(menuItems, foundation.MenuItem{
+			Name: fmt.Sprintf("Install %s (%s)", implant.Description, implant.Slot.String()),
+			Action: func() {
+				g.playerInstallImplant(item)
+			},
+			CloseMenus: true,
+		})
+	}
+	return menuItems
+}
+
+// clinicInstallImplants is the paid installation by an NPC doctor. All carried implants with a free slot are installed.
+func (g *GameState) clinicInstallImplants(doctor *Actor, price int) {
+	installed := 0
+	for _, item := range g.getImplantItems() {
+		implant, isValid := g.getImplantForItem(item)
+		if !isValid || !g.Player.IsImplantSlotFree(implant.Slot) {
+			continue
+		}
+		if !g.chargePlayer(doctor, price) {
+			g.msg(foundation.Msg("You can't afford any more surgery"))
+			break
+		}
+		g.removeItemFromInventory(g.Player, item)
+		g.Player.installImplant(implant, item.InternalName())
+		g.msg(foundation.HiLite("You paid %s caps for the installation of %s", fmt.Sprint(price), implant.Description))
+		installed++
+	}
+	if installed == 0 {
+		g.msg(foundation.Msg("There is nothing to install"))
+	}
+	g.updateUIStatus()
+}
+
+// clinicRemoveImplants is the paid removal of all implants by an NPC doctor. The implants are given back to the player.
+func (g *GameState) clinicRemoveImplants(doctor *Actor, price int) {
+	for _, implant := range g.Player.GetInstalledImplants() {
+		if !g.chargePlayer(doctor, price) {
+			g.msg(foundation.Msg("You can't afford any more surgery"))
+			break
+		}
+		g.Player.removeImplant(implant.Slot)
+		g.Player.GetInventory().AddItem(g.NewItemFromString(implant.ItemName))
+		g.msg(foundation.HiLite("You paid %s caps for the removal of %s", fmt.Sprint(price), implant.Description))
+	}
+	g.afterImplantsChanged()
+}
+
+func (g *GameState) canPlayerInstallImplant() bool {
+	for _, item := range g.getImplantItems() {
+		if implant, isValid := g.getImplantForItem(item); isValid && g.Player.IsImplantSlotFree(implant.Slot) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPlayerImplants describes the installed implants for the character sheet.
+func (g *GameState) GetPlayerImplants() []string {
+	var result []string
+	for _, implant := range g.Player.GetInstalledImplants() {
+		line := fmt.Sprintf("%s (%s)", implant.Description, implant.Slot.String())
+		if implant.IsDisabled() {
+			line += " - disabled"
+		} else if implant.IsActive {
+			line += " - active"
+		}
+		result = append(result, line)
+	}
+	return result
+}
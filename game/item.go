@@ -31,8 +31,11 @@ type GenericItem struct {
 	charges int
 
 	statChanges StatChange
+	drugEffect  DrugEffect
+	implant     string
 
 	equipFlag    foundation.ActorFlag
+	equipSlot    foundation.EquipSlot
 	thrownDamage fxtools.Interval
 	tags         foundation.ItemTags
 	textFile     string
@@ -173,6 +176,15 @@ func (i *GenericItem) GobEncode() ([]byte, error) {
 	if err := encoder.Encode(i.alive); err != nil {
 		return nil, err
 	}
+	if err := encoder.Encode(i.drugEffect); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(i.implant); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(i.equipSlot); err != nil {
+		return nil, err
+	}
 
 	return buf.Bytes(), nil
 }
@@ -257,6 +269,15 @@ func (i *GenericItem) GobDecode(data []byte) error {
 	if err := decoder.Decode(&i.alive); err != nil {
 		return err
 	}
+	if err := decoder.Decode(&i.drugEffect); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&i.implant); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&i.equipSlot); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -514,7 +535,30 @@ func (i *GenericItem) CanStackWith(other foundation.Item) bool {
 }
 
 func (i *GenericItem) IsEquippable() bool {
-	return false
+	return i.GetEquipSlot() != foundation.SlotNameNotEquippable
+}
+
+// GetEquipSlot is the slot from the item definition. Light sources and watches don't need one.
+func (i *GenericItem) GetEquipSlot() foundation.EquipSlot {
+	if i.equipSlot != foundation.SlotNameNotEquippable {
+		return i.equipSlot
+	}
+	if i.IsLightSource() {
+		return foundation.SlotNameLightSource
+	}
+	if i.IsWatch() {
+		return foundation.SlotNameAccessoryOne
+	}
+	return foundation.SlotNameNotEquippable
+}
+
+func (i *GenericItem) IsAccessory() bool {
+	return i.GetEquipSlot().IsAccessorySlot()
+}
+
+// CanBePutOnBelt is true for everything that can be used with a single key: grenades, chems and lockpicks.
+func (i *GenericItem) CanBePutOnBelt() bool {
+	return i.IsUsableOrZappable() || i.IsLockpick()
 }
 
 func (i *GenericItem) IsMeleeWeapon() bool {
@@ -556,6 +600,11 @@ func (i *GenericItem) IsDrug() bool {
 	return i.charges > 0 && i.category == foundation.ItemCategoryConsumables && (len(i.statChanges.StatChanges) > 0 || len(i.statChanges.SkillChanges) > 0 || len(i.statChanges.DerivedStatChanges) > 0)
 }
 
+// IsImplant is true for items that can be installed as cyberware.
+func (i *GenericItem) IsImplant() bool {
+	return i.implant != ""
+}
+
 func (i *GenericItem) InternalName() string {
 	return i.internalName
 }
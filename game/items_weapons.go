@@ -82,6 +82,10 @@ func (i *Weapon) IsRangedWeapon() bool {
 	return i.weaponType.IsRanged()
 }
 
+func (i *Weapon) IsTwoHanded() bool {
+	return i.weaponType.IsTwoHanded()
+}
+
 func (i *Weapon) IsMeleeWeapon() bool {
 	return i.weaponType.IsMelee()
 }
@@ -377,6 +381,10 @@ func (t WeaponType) IsRanged() bool {
 	return t.IsMissile() || t == WeaponTypeBow || t == WeaponTypeCrossbow || t == WeaponTypePistol || t == WeaponTypeRifle || t == WeaponTypeShotgun || t == WeaponTypeSMG || t == WeaponTypeMinigun || t == WeaponTypeRocketLauncher || t == WeaponTypeBigGun || t == WeaponTypeEnergy
 }
 
+func (t WeaponType) IsTwoHanded() bool {
+	return t == WeaponTypeBow || t == WeaponTypeCrossbow || t == WeaponTypeRifle || t == WeaponTypeShotgun || t == WeaponTypeSledgehammer || t == WeaponTypeMinigun || t == WeaponTypeRocketLauncher || t == WeaponTypeBigGun || t == WeaponTypeSpear
+}
+
 func (t WeaponType) IsMelee() bool {
 	return t == WeaponTypeSword || t == WeaponTypeClub || t == WeaponTypeAxe || t == WeaponTypeDagger || t == WeaponTypeSpear || t == WeaponTypeKnife || t == WeaponTypeMelee
 }
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+)
+
+// Learning by doing is only active, when it is enabled in the config. Successful uses of a skill give progress
+// towards the next skill point, harder tasks give more. The config ratio splits the growth between the
+// progress from using the skills and the skill points from level ups.
+const (
+	learnGainTask   = 20
+	learnGainCombat = 4
+)
+
+// learnByDoing is called after a successful use of a skill. The roll modifier is the difficulty of the task,
+// negative values are harder and give more progress.
+func (g *GameState) learnByDoing(skill special.Skill, baseGain int, rollModifier int) {
+	if !g.config.LearnByDoing {
+		return
+	}
+	gain := (baseGain + max(0, -rollModifier)/2) * g.config.LearnByDoingRatio / 100
+	if g.Player.GetCharSheet().AddSkillProgress(skill, gain) > 0 {
+		g.msg(foundation.HiLite("Practice makes perfect, your %s skill improved", skill.String()))
+		g.updateUIStatus()
+	}
+}
+
+// withholdLevelUpSkillPoints removes the share of the skill points of a level up, that is learned by doing instead.
+func (g *GameState) withholdLevelUpSkillPoints() {
+	if !g.config.LearnByDoing {
+		return
+	}
+	charSheet := g.Player.GetCharSheet()
+	withheld := charSheet.GetDerivedStat(special.SkillRate) * g.config.LearnByDoingRatio / 100
+	charSheet.AddSkillPoints(-min(withheld, charSheet.GetSkillPointsToSpend()))
+}
+
+// attackSkill is the skill that is trained by hitting with the weapon.
+func attackSkill(weapon *Weapon) special.Skill {
+	if weapon != nil && weapon.IsWeapon() {
+		return weapon.GetSkillUsed()
+	}
+	return special.MeleeCombat
+}
+
+// IsLearningByDoing tells the character sheet to show the skill progress bars.
+func (g *GameState) IsLearningByDoing() bool {
+	return g.config.LearnByDoing
+}
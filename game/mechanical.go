@@ -0,0 +1,216 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"fmt"
+)
+
+// Robots, turrets and drones are actors with a mechanical body.
+// They have their own body structure, they don't breathe or bleed and they can't be intimidated.
+// An EMP will knock them out for a while and a skilled technician can reprogram or shut them down.
+// Turrets are usually also stationary and can be linked to an alarm, eg.
+//
+//	Body: mechanical
+//	Flags: stationary
+//	Alarm: lab_alarm
+//
+// They will then open fire on the player while the alarm is active.
+
+const (
+	technologyReprogramPenalty = -30
+	technologyDisabledBonus    = 40
+)
+
+func (a *Actor) IsMechanical() bool {
+	return a.HasFlag(foundation.FlagMechanical)
+}
+
+func (a *Actor) IsStationary() bool {
+	return a.HasFlag(foundation.FlagStationary)
+}
+
+// IsDisabled is true for machines that have been shut down or knocked out by an EMP.
+func (a *Actor) IsDisabled() bool {
+	return a.HasFlag(foundation.FlagShutDown) || a.HasFlag(foundation.FlagEMPDisabled)
+}
+
+func (a *Actor) IsImmuneTo(damageType special.DamageType) bool {
+	if a.IsMechanical() {
+		return damageType == special.DamageTypePoison || damageType == special.DamageTypeRadiation
+	}
+	return false
+}
+
+// SetMechanical turns the actor into a machine.
+func (a *Actor) SetMechanical() {
+	a.statusFlags.Set(foundation.FlagMechanical)
+	a.body = special.MechanicalBodyParts
+}
+
+// mainBodyPart maps hits on body parts the actor doesn't have, eg. a headshot on a turret, to its main body part.
+func (a *Actor) mainBodyPart(part special.BodyPart) special.BodyPart {
+	if len(a.body) == 0 || a.GetBodyPartIndex(part) != -1 {
+		return part
+	}
+	return a.body[0]
+}
+
+func (a *Actor) SetAlarmLink(alarmFlag string) {
+	a.alarmLink = alarmFlag
+}
+
+func (a *Actor) GetAlarmLink() string {
+	return a.alarmLink
+}
+
+// empDisable knocks the machine out for a few turns, the duration depends on the damage.
+func (g *GameState) empDisable(victim *Actor, damageAmount int) {
+	turns := 3 + damageAmount/5
+	victim.GetFlags().Increase(foundation.FlagEMPDisabled, turns)
+	victim.StopInvestigating()
+	if g.couldPlayerSeeActor(victim) {
+		g.msg(foundation.HiLite("%s shuts down", victim.Name()))
+	}
+}
+
+// updateAlarmLink makes linked machines react when their alarm goes on or off.
+// While the alarm is active, they target the player. Afterwards they return to standby.
+func (g *GameState) updateAlarmLink(machine *Actor) {
+	if machine.alarmLink == "" {
+		return
+	}
+	alarmActive := g.IsAlarmActive(machine.alarmLink)
+	if alarmActive == machine.alarmLinkActive {
+		return
+	}
+	machine.alarmLinkActive = alarmActive
+	if machine.HasFlag(foundation.FlagShutDown) || machine.IsAlliedWith(g.Player) {
+		return
+	}
+	if alarmActive {
+		machine.SetHostileTowards(g.Player)
+		machine.ChangeAlertLevel(alertLevelMax)
+		if g.canPlayerSee(machine.Position()) {
+			g.msg(foundation.HiLite("%s powers up", machine.Name()))
+		}
+	} else {
+		machine.RemoveFromEnemyActors(g.Player.GetInternalName())
+		machine.SetNeutral()
+		machine.SetGoal(NoGoal)
+		machine.StopInvestigating()
+	}
+}
+
+// technologyModifier makes it easier to work on machines that are disabled or not looking at the player.
+func (g *GameState) technologyModifier(machine *Actor) int {
+	if machine.IsDisabled() {
+		return technologyDisabledBonus
+	}
+	return g.stealthFacingModifier(machine)
+}
+
+func (g *GameState) technologyChance(machine *Actor, modifier int) special.Percentage {
+	skill := g.Player.GetCharSheet().GetSkill(special.Technology) + g.technologyModifier(machine) + modifier
+	return special.Percentage(max(0, min(95, skill)))
+}
+
+func (g *GameState) appendContextActionsForMachine(buffer []foundation.MenuItem, machine *Actor) []foundation.MenuItem {
+	distance := g.currentMap().MoveDistance(g.Player.Position(), machine.Position())
+	if distance > 1 || (machine.IsEnemyOf(g.Player) && !machine.IsDisabled()) {
+		return buffer
+	}
+
+	if machine.HasFlag(foundation.FlagShutDown) {
+		buffer = append(buffer, foundation.MenuItem{
+			Name: "Reactivate",
+			Action: func() {
+				g.playerReactivate(machine)
+			},
+			CloseMenus: true,
+		})
+	} else {
+		buffer = append(buffer, foundation.MenuItem{
+			Name: fmt.Sprintf("Shut Down (%s)", g.technologyChance(machine, 0)),
+			Action: func() {
+				g.playerShutDown(machine)
+			},
+			CloseMenus: true,
+		})
+	}
+
+	if !machine.IsAlliedWith(g.Player) {
+		buffer = append(buffer, foundation.MenuItem{
+			Name: fmt.Sprintf("Reprogram (%s)", g.technologyChance(machine, technologyReprogramPenalty)),
+			Action: func() {
+				g.playerReprogram(machine)
+			},
+			CloseMenus: true,
+		})
+	}
+
+	if !machine.IsEnemyOf(g.Player) {
+		buffer = append(buffer, foundation.MenuItem{
+			Name: "Melee Attack",
+			Action: func() {
+				g.playerMeleeAttack(machine)
+			},
+			CloseMenus: true,
+		})
+	}
+	return buffer
+}
+
+// onTechnologyFailure sets off the defense protocols of the machine, unless it's disabled.
+func (g *GameState) onTechnologyFailure(machine *Actor, result special.CheckResult) {
+	if machine.IsDisabled() || !result.Crit {
+		g.msg(foundation.HiLite("You fail to get access to %s", machine.Name()))
+		return
+	}
+	g.msg(foundation.HiLite("You trigger the defense protocols of %s", machine.Name()))
+	g.trySetHostile(machine, g.Player)
+}
+
+func (g *GameState) playerShutDown(machine *Actor) {
+	result := special.SuccessRoll(g.technologyChance(machine, 0), special.Percentage(g.Player.GetCharSheet().GetDerivedStat(special.CriticalChance)))
+	if result.Success {
+		machine.GetFlags().Set(foundation.FlagShutDown)
+		machine.SetNeutral()
+		machine.SetGoal(NoGoal)
+		machine.StopInvestigating()
+		g.msg(foundation.HiLite("You shut down %s", machine.Name()))
+		g.learnByDoing(special.Technology, learnGainTask, g.technologyModifier(machine))
+	} else {
+		g.onTechnologyFailure(machine, result)
+	}
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+func (g *GameState) playerReactivate(machine *Actor) {
+	machine.GetFlags().Unset(foundation.FlagShutDown)
+	g.msg(foundation.HiLite("You reactivate %s", machine.Name()))
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+// playerReprogram makes the machine fight for the player.
+func (g *GameState) playerReprogram(machine *Actor) {
+	result := special.SuccessRoll(g.technologyChance(machine, technologyReprogramPenalty), special.Percentage(g.Player.GetCharSheet().GetDerivedStat(special.CriticalChance)))
+	if result.Success {
+		g.reprogramToFriendly(machine)
+		g.msg(foundation.HiLite("You reprogram %s to protect you", machine.Name()))
+		g.learnByDoing(special.Technology, learnGainTask, g.technologyModifier(machine)+technologyReprogramPenalty)
+	} else {
+		g.onTechnologyFailure(machine, result)
+	}
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+func (g *GameState) reprogramToFriendly(machine *Actor) {
+	machine.GetFlags().Unset(foundation.FlagShutDown)
+	machine.SetTeam(g.Player.GetTeam())
+	machine.RemoveFromEnemyActors(g.Player.GetInternalName())
+	machine.SetAIState(foundation.AttackEnemies)
+	machine.SetGoal(NoGoal)
+	machine.StopInvestigating()
+	machine.alertLevel = 0
+}
@@ -0,0 +1,178 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"fmt"
+)
+
+const (
+	firstAidUsesPerDay    = 3
+	firstAidTimeName      = "FirstAid"
+	doctorsBagItemName    = "doctors_bag"
+	doctorRollModifier    = -20
+	bleedingTurns         = 10
+	bleedingDamageDivider = 4 // hits dealing more than a quarter of the max. hit points cause bleeding
+)
+
+// firstAidUsesLeft resets the uses once a day has passed since the first use.
+func (g *GameState) firstAidUsesLeft() int {
+	if g.IsDaysAfter(firstAidTimeName, 1) {
+		return firstAidUsesPerDay
+	}
+	return max(0, firstAidUsesPerDay-g.Player.GetFlags().Get(foundation.FlagFirstAidUses))
+}
+
+func (g *GameState) useFirstAid() {
+	flags := g.Player.GetFlags()
+	if g.IsDaysAfter(firstAidTimeName, 1) {
+		g.SaveTimeNow(firstAidTimeName)
+		flags.Unset(foundation.FlagFirstAidUses)
+	}
+	flags.Increment(foundation.FlagFirstAidUses)
+}
+
+// playerFirstAid restores hit points and stops bleeding.
+// A critical success heals twice as much, a critical failure makes the bleeding worse.
+func (g *GameState) playerFirstAid() {
+	player := g.Player
+	if !player.IsWounded() && !player.HasFlag(foundation.FlagBleeding) {
+		g.msg(foundation.Msg("You don't need first aid"))
+		return
+	}
+	if g.firstAidUsesLeft() == 0 {
+		g.msg(foundation.Msg("You have used up your first aid for today"))
+		return
+	}
+	g.useFirstAid()
+
+	charSheet := player.GetCharSheet()
+	result := charSheet.SkillRoll(special.Biology, 0)
+	healAmount := 1 + charSheet.GetSkill(special.Biology)/10
+	switch {
+	case result.IsCriticalSuccess():
+		player.Heal(healAmount * 2)
+		player.GetFlags().Unset(foundation.FlagBleeding)
+		g.msg(foundation.HiLite("You expertly dress your wounds and heal %s hit points", fmt.Sprint(healAmount*2)))
+	case result.Success:
+		player.Heal(healAmount)
+		player.GetFlags().Unset(foundation.FlagBleeding)
+		g.msg(foundation.HiLite("You dress your wounds and heal %s hit points", fmt.Sprint(healAmount)))
+	case result.Crit:
+		player.GetFlags().Increase(foundation.FlagBleeding, bleedingTurns)
+		g.msg(foundation.Msg("You tear the wound open and start bleeding"))
+	default:
+		g.msg(foundation.Msg("You fail to treat your wounds"))
+	}
+	g.endPlayerTurn(player.timeNeededForActions())
+}
+
+// playerDoctor heals a crippled body part. It needs a doctor's bag, which is used up with a critical failure.
+func (g *GameState) playerDoctor(part special.BodyPart) {
+	player := g.Player
+	bag := player.GetInventory().GetItemByName(doctorsBagItemName)
+	if bag == nil {
+		g.msg(foundation.Msg("You need a doctor's bag to treat crippled limbs"))
+		return
+	}
+	if !g.hasPaidWithCharge(player, bag) {
+		return
+	}
+
+	result := player.GetCharSheet().SkillRoll(special.Biology, doctorRollModifier)
+	switch {
+	case result.IsCriticalSuccess():
+		player.healBodyPart(part)
+		player.Heal(player.GetHitPointsMax() / 4)
+		g.msg(foundation.HiLite("You set your %s perfectly and feel much better", part.String()))
+	case result.Success:
+		player.healBodyPart(part)
+		g.msg(foundation.HiLite("You treat your crippled %s", part.String()))
+	case result.Crit:
+		player.GetInventory().RemoveItem(bag)
+		g.msg(foundation.HiLite("You botch the treatment of your %s and ruin your doctor's bag", part.String()))
+	default:
+		g.msg(foundation.HiLite("You fail to treat your crippled %s", part.String()))
+	}
+	g.endPlayerTurn(player.timeNeededForActions())
+}
+
+// chargePlayer moves the price from the player to the doctor. It is false, if the player can't afford it.
+func (g *GameState) chargePlayer(doctor *Actor, price int) bool {
+	if !g.Player.HasGold(price) {
+		return false
+	}
+	g.Player.RemoveGold(price)
+	if doctor != nil {
+		doctor.GetFlags().Increase(foundation.FlagGold, price)
+	}
+	return true
+}
+
+// doctorTreatment is the full treatment by an NPC doctor: all hit points, all limbs and no more bleeding.
+func (g *GameState) doctorTreatment(doctor *Actor, price int) {
+	if !g.chargePlayer(doctor, price) {
+		g.msg(foundation.Msg("You can't afford the treatment"))
+		return
+	}
+	g.Player.Heal(g.Player.GetHitPointsMax())
+	for _, part := range g.Player.getCrippledBodyParts() {
+		g.Player.healBodyPart(part)
+	}
+	g.Player.GetFlags().Unset(foundation.FlagBleeding)
+	g.msg(foundation.HiLite("You paid %s caps and feel much better", fmt.Sprint(price)))
+}
+
+// tryStartBleeding is called for every hit. Heavy hits and crippling hits on living tissue cause bleeding.
+func (g *GameState) tryStartBleeding(victim *Actor, damage SourcedDamage, didCripple bool) {
+	if !victim.IsAlive() || victim.IsMechanical() || victim.HasFlag(foundation.FlagZombie) || damage.DamageType != special.DamageTypeNormal {
+		return
+	}
+	if !didCripple && damage.DamageAmount <= victim.GetHitPointsMax()/bleedingDamageDivider {
+		return
+	}
+	if victim == g.Player && !victim.HasFlag(foundation.FlagBleeding) {
+		g.msg(foundation.Msg("You are bleeding!"))
+	}
+	victim.GetFlags().Increase(foundation.FlagBleeding, bleedingTurns)
+}
+
+// updateBleeding costs every bleeding actor one hit point per turn, until the bleeding stops on its own or is treated.
+func (g *GameState) updateBleeding() {
+	var animations []foundation.Animation
+	for _, actor := range g.currentMap().Actors() {
+		if !actor.IsAlive() || !actor.HasFlag(foundation.FlagBleeding) {
+			continue
+		}
+		actor.GetFlags().Decrement(foundation.FlagBleeding)
+		damage := SourcedDamage{
+			NameOfThing:     "bleeding",
+			IsObviousAttack: false,
+			TargetingMode:   special.TargetingModeFireSingle,
+			DamageType:      special.DamageTypeNormal,
+			DamageAmount:    1,
+			BodyPart:        special.Body,
+		}
+		animations = append(animations, g.damageActor(damage, actor)...)
+	}
+	g.ui.AddAnimations(animations)
+}
+
+func (a *Actor) getCrippledBodyParts() []special.BodyPart {
+	var crippled []special.BodyPart
+	for _, part := range a.body {
+		if a.IsCrippled(part) {
+			crippled = append(crippled, part)
+		}
+	}
+	return crippled
+}
+
+// NeedsHealing is true for any injury a doctor can treat.
+func (a *Actor) NeedsHealing() bool {
+	return a.IsWounded() || a.HasFlag(foundation.FlagBleeding) || len(a.getCrippledBodyParts()) > 0
+}
+
+func (a *Actor) healBodyPart(part special.BodyPart) {
+	delete(a.bodyDamage, part)
+}
@@ -0,0 +1,167 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"fmt"
+	"github.com/memmaker/go/geometry"
+	"math/rand"
+)
+
+const (
+	moraleBreakThreshold     = 30
+	moraleSurrenderThreshold = 10
+	moraleOddsRadius         = 8
+)
+
+func isAllyOf(one, two *Actor) bool {
+	if one == two {
+		return false
+	}
+	if one.squadName != "" && one.squadName == two.squadName {
+		return true
+	}
+	if one.GetTeam() != "" || two.GetTeam() != "" {
+		return one.GetTeam() == two.GetTeam()
+	}
+	return one.GetInternalName() == two.GetInternalName()
+}
+
+// moraleOddsPenalty compares the number of opponents around the actor to the number of its allies.
+func (g *GameState) moraleOddsPenalty(actor *Actor) int {
+	opponents := 0
+	allies := 1
+	nearby := g.currentMap().GetFilteredActorsInRadius(actor.Position(), moraleOddsRadius, func(other *Actor) bool {
+		return other != actor && other.IsAlive() && !other.IsSurrendered()
+	})
+	for _, other := range nearby {
+		if isAllyOf(actor, other) {
+			allies++
+		} else if actor.WantsToAttack(other) || other.IsHostileTowards(actor) {
+			opponents++
+		}
+	}
+	return max(0, opponents-allies) * 10
+}
+
+// onAllyDeath lowers the morale of everyone who saw one of their own go down.
+func (g *GameState) onAllyDeath(victim *Actor) {
+	for _, witness := range g.currentMap().Actors() {
+		if witness == g.Player || !witness.IsAlive() || !isAllyOf(witness, victim) || !g.isInVisionOf(witness, victim.Position()) {
+			continue
+		}
+		witness.ChangeMorale(-20)
+		g.checkMorale(witness)
+	}
+}
+
+// onMoraleDamage is called after an NPC took damage and survived.
+func (g *GameState) onMoraleDamage(victim *Actor, damage SourcedDamage, didCripple bool) {
+	if victim == g.Player || victim.HasFlag(foundation.FlagZombie) || victim.IsMechanical() {
+		return
+	}
+	moraleLoss := 0
+	if didCripple {
+		moraleLoss += 25
+	}
+	badlyWounded := victim.GetHitPointsMax() / 3
+	hitPointsBefore := victim.GetHitPoints() + damage.DamageAmount
+	if hitPointsBefore >= badlyWounded && victim.GetHitPoints() < badlyWounded {
+		moraleLoss += 20
+	}
+	if moraleLoss == 0 && !victim.IsBegging() {
+		return
+	}
+	victim.ChangeMorale(-moraleLoss)
+	g.checkMorale(victim)
+}
+
+// checkMorale decides if the actor keeps fighting.
+// Broken actors flee if they can, otherwise they beg for mercy. Begging actors that are pushed further surrender.
+func (g *GameState) checkMorale(actor *Actor) {
+	if actor == g.Player || !actor.IsAlive() || actor.IsSurrendered() || actor.HasFlag(foundation.FlagZombie) || actor.IsMechanical() {
+		return
+	}
+	effectiveMorale := actor.GetMorale() - g.moraleOddsPenalty(actor)
+	if effectiveMorale >= moraleBreakThreshold {
+		return
+	}
+	if effectiveMorale < moraleSurrenderThreshold || actor.IsBegging() {
+		g.actorSurrender(actor)
+		return
+	}
+	if actor.HasFlag(foundation.FlagScared) {
+		return
+	}
+	fleePos := g.currentMap().GetMoveOnPlayerDijkstraMap(actor.Position(), false, g.playerDijkstraMap)
+	if fleePos != actor.Position() {
+		actor.GetFlags().Set(foundation.FlagScared)
+		if g.canPlayerSee(actor.Position()) {
+			g.msg(foundation.HiLite("%s panics and runs", actor.Name()))
+		}
+		return
+	}
+	g.actorBeg(actor)
+}
+
+func (g *GameState) actorBeg(actor *Actor) {
+	actor.GetFlags().Set(foundation.FlagBegging)
+	actor.SetGoal(NoGoal)
+	actor.SetAIState(foundation.Panic)
+	if g.canPlayerSee(actor.Position()) {
+		g.msg(foundation.HiLite("%s begs for mercy", actor.Name()))
+	}
+}
+
+// actorSurrender makes the actor drop its weapons and stop fighting for good.
+func (g *GameState) actorSurrender(actor *Actor) {
+	actor.GetFlags().Unset(foundation.FlagScared)
+	actor.GetFlags().Unset(foundation.FlagBegging)
+	actor.GetFlags().Set(foundation.FlagSurrendered)
+	actor.SetGoal(NoGoal)
+	actor.SetNeutral()
+
+	for _, equipped := range actor.GetEquipment().AllItems() {
+		if weapon, isWeapon := equipped.(*Weapon); isWeapon {
+			g.actorDropItem(actor, weapon)
+		}
+	}
+
+	g.gameFlags.SetFlag(fmt.Sprintf("Surrendered(%s)", actor.GetInternalName()))
+	if g.canPlayerSee(actor.Position()) {
+		g.msg(foundation.HiLite("%s surrenders", actor.Name()))
+	}
+	g.ui.UpdateVisibleActors()
+}
+
+// actBegging keeps the actor cowering until no enemy is near, then it will slowly pull itself together.
+func (g *GameState) actBegging(actor *Actor) int {
+	distanceToPlayer := geometry.DistanceChebyshev(actor.Position(), g.Player.Position())
+	if distanceToPlayer > moraleOddsRadius && rand.Intn(3) == 0 {
+		actor.ChangeMorale(10)
+		if actor.GetMorale() >= moraleBreakThreshold {
+			actor.GetFlags().Unset(foundation.FlagBegging)
+			actor.SetAIState(foundation.AttackEnemies)
+		}
+	} else if g.canPlayerSee(actor.Position()) && rand.Intn(5) == 0 {
+		g.tryAddChatter(actor, "Please, don't kill me!")
+	}
+	return actor.timeEnergy
+}
+
+func (g *GameState) playerIntimidate(victim *Actor) {
+	intimidation := special.Percentage(g.Player.GetCharSheet().GetSkill(special.Intimidate))
+	resolve := special.Percentage(victim.GetMorale())
+	attackerCritChance := special.Percentage(g.Player.GetCharSheet().GetDerivedStat(special.CriticalChance))
+	defenderCritChance := special.Percentage(victim.GetCharSheet().GetDerivedStat(special.CriticalChance))
+
+	if special.SkillContest(intimidation, attackerCritChance, resolve, defenderCritChance) == 0 {
+		g.msg(foundation.HiLite("%s flinches", victim.Name()))
+		victim.ChangeMorale(-25)
+		g.checkMorale(victim)
+	} else {
+		g.msg(foundation.HiLite("%s is not impressed", victim.Name()))
+		victim.ChangeMorale(5)
+	}
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
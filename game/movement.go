@@ -16,6 +16,15 @@ func (g *GameState) TryGetDoorAt(mapPos geometry.Point) (*Door, bool) {
 	}
 	return nil, false
 }
+
+func (g *GameState) TryGetDoorByName(internalName string) (*Door, bool) {
+	for _, obj := range g.currentMap().Objects() {
+		if door, isDoor := obj.(*Door); isDoor && door.GetInternalName() == internalName {
+			return door, true
+		}
+	}
+	return nil, false
+}
 func (g *GameState) ManualMovePlayer(direction geometry.CompassDirection) {
 	if !g.config.DiagonalMovementEnabled && direction.IsDiagonal() {
 		return
@@ -152,7 +161,7 @@ func (g *GameState) ManualMovePlayer(direction geometry.CompassDirection) {
 	}
 
 	if actorAt, exists := g.currentMap().TryGetActorAt(newPos); exists {
-		if actorAt.IsHostileTowards(g.Player) {
+		if actorAt.IsEnemyOf(g.Player) {
 			g.playerMeleeAttack(actorAt)
 		} else {
 			g.OpenContextMenuFor(actorAt.Position())
@@ -218,22 +227,30 @@ func (g *GameState) openInventoryOf(actor *Actor) {
 	playerItems := g.Player.GetInventory().Items()
 	g.ui.ShowGiveAndTakeContainer(g.Player.Name(), playerItems, actor.Name(), actorItems, rightToLeft, leftToRight)
 }
+
+// updatePlayerLightSource follows the player with the light of a carried lamp or the night vision implant.
+func (g *GameState) updatePlayerLightSource() {
+	if g.Player.GetEquipment().HasLightSource() || g.Player.IsImplantAbilityActive(ImplantAbilityNightVision) {
+		g.playerLightSource.MaxIntensity = 1
+		g.currentMap().MoveLightSource(g.playerLightSource, g.Player.Position())
+	} else if g.playerLightSource.MaxIntensity > 0 {
+		g.playerLightSource.MaxIntensity = 0
+		g.currentMap().UpdateDynamicLights()
+	}
+}
+
 func (g *GameState) afterPlayerMoved(oldPos geometry.Point, wasMapTransition bool) {
 	// explore the map
 	// print "You see.." message
 	if g.currentMap().IsItemAt(g.Player.Position()) && g.config.AutoPickup {
 		g.PlayerPickupItem()
 	}
-	if g.Player.GetInventory().HasLightSource() || g.Player.IsCyberWareActive(CyberWareLight) {
-		g.playerLightSource.MaxIntensity = 1
-		g.currentMap().MoveLightSource(g.playerLightSource, g.Player.Position())
-	} else {
-		g.playerLightSource.MaxIntensity = 0
-	}
+	g.updatePlayerLightSource()
 
 	g.msg(g.GetMapInfoForMovement(g.Player.Position()))
 	g.updateDijkstraMap()
 	g.updatePlayerFoVAndApplyExploration()
+	g.passiveSearch()
 
 	if g.Player.HasFlag(foundation.FlagCurseTeleportitis) && rand.Intn(100) < 5 {
 		g.ui.AddAnimations(OneAnimation(teleportWithAnimation(g, g.Player, g.currentMap().RandomSpawnPosition())))
@@ -246,6 +263,7 @@ func (g *GameState) afterPlayerMoved(oldPos geometry.Point, wasMapTransition boo
 		if door, exists := g.TryGetDoorAt(g.Player.Position()); exists {
 			if door.IsClosedButNotLocked() {
 				door.PlayOpenSfx()
+				g.makeNoise(door.Position(), noiseRadiusDoor, g.Player)
 			}
 		}
 		if door, exists := g.TryGetDoorAt(oldPos); exists {
@@ -255,6 +273,12 @@ func (g *GameState) afterPlayerMoved(oldPos geometry.Point, wasMapTransition boo
 		}
 	}
 
+	if wasMapTransition {
+		g.resetTriggerOccupants()
+		g.resetSquadKnowledge()
+		g.resetActorVision()
+	}
+
 	// check transition
 	if !wasMapTransition {
 		g.CheckTransition()
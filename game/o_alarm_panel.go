@@ -0,0 +1,164 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"strings"
+)
+
+// AlarmPanel is a wall panel that sets off one of the alarms of the map.
+// Frightened NPCs run to the nearest panel, the player can use it too or sabotage it.
+type AlarmPanel struct {
+	*BaseObject
+	alarmName  string
+	isDisabled bool
+
+	trigger func(actor *Actor)
+}
+
+func (p *AlarmPanel) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := p.BaseObject.gobEncode(enc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(p.alarmName); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(p.isDisabled); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (p *AlarmPanel) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	p.BaseObject = &BaseObject{}
+
+	if err := p.BaseObject.gobDecode(dec); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&p.alarmName); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&p.isDisabled); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *GameState) NewAlarmPanel(rec recfile.Record) *AlarmPanel {
+	panel := &AlarmPanel{
+		BaseObject: NewObject(foundation.ObjectAlarmPanel, g.iconForObject),
+	}
+	panel.displayName = "an alarm panel"
+	panel.SetWalkable(false)
+	panel.SetHidden(false)
+	panel.SetTransparent(true)
+
+	for _, field := range rec {
+		switch strings.ToLower(field.Name) {
+		case "name":
+			panel.internalName = field.Value
+		case "description":
+			panel.displayName = field.Value
+		case "position":
+			panel.position, _ = geometry.NewPointFromEncodedString(field.Value)
+		case "alarm":
+			panel.alarmName = field.Value
+		case "disabled":
+			panel.isDisabled = field.AsBool()
+		}
+	}
+
+	panel.InitWithGameState(g)
+	return panel
+}
+
+func (p *AlarmPanel) InitWithGameState(g *GameState) {
+	p.iconForObject = g.iconForObject
+	p.trigger = func(actor *Actor) {
+		g.triggerAlarm(p.alarmName, actor, p.Position())
+	}
+}
+
+func (p *AlarmPanel) Name() string {
+	if p.isDisabled {
+		return p.displayName + " (disabled)"
+	}
+	return p.displayName
+}
+
+func (p *AlarmPanel) GetAlarmName() string {
+	return p.alarmName
+}
+
+func (p *AlarmPanel) IsDisabled() bool {
+	return p.isDisabled
+}
+
+// Trigger sets off the alarm, unless the panel was sabotaged.
+func (p *AlarmPanel) Trigger(actor *Actor) bool {
+	if p.isDisabled || p.trigger == nil {
+		return false
+	}
+	p.trigger(actor)
+	return true
+}
+
+func (p *AlarmPanel) OnDamage(dmg SourcedDamage) []foundation.Animation {
+	if dmg.DamageAmount > 0 && dmg.DamageType != special.DamageTypePoison && dmg.DamageType != special.DamageTypeRadiation {
+		p.isDisabled = true
+	}
+	return nil
+}
+
+func (p *AlarmPanel) AppendContextActions(items []foundation.MenuItem, g *GameState) []foundation.MenuItem {
+	if p.isDisabled || g.currentMap().MoveDistance(g.Player.Position(), p.Position()) > 1 {
+		return items
+	}
+	sabotageChance := g.Player.GetCharSheet().GetSkill(special.Mechanics)
+	return append(items, foundation.MenuItem{
+		Name: "Sound Alarm",
+		Action: func() {
+			p.Trigger(g.Player)
+			g.endPlayerTurn(g.Player.timeNeededForActions())
+		},
+		CloseMenus: true,
+	}, foundation.MenuItem{
+		Name: fmt.Sprintf("Sabotage (%d%%)", sabotageChance),
+		Action: func() {
+			g.playerSabotageAlarmPanel(p)
+		},
+		CloseMenus: true,
+	})
+}
+
+func (p *AlarmPanel) ToRecord() recfile.Record {
+	rec := recfile.Record{
+		{Name: "category", Value: p.category.LowerString()},
+		{Name: "position", Value: p.position.Encode()},
+		{Name: "description", Value: p.displayName},
+		{Name: "alarm", Value: p.alarmName},
+	}
+	if p.internalName != "" {
+		rec = append(rec, recfile.Field{Name: "name", Value: p.internalName})
+	}
+	if p.isDisabled {
+		rec = append(rec, recfile.Field{Name: "disabled", Value: recfile.BoolStr(true)})
+	}
+	return rec
+}
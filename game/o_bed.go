@@ -0,0 +1,89 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"bytes"
+	"encoding/gob"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"strings"
+)
+
+// Bed is the only place where the player can sleep. Sleeping removes fatigue and heals.
+type Bed struct {
+	*BaseObject
+}
+
+func (b *Bed) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := b.BaseObject.gobEncode(enc); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (b *Bed) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	b.BaseObject = &BaseObject{}
+
+	if err := b.BaseObject.gobDecode(dec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *GameState) NewBed(rec recfile.Record) *Bed {
+	bed := &Bed{
+		BaseObject: NewObject(foundation.ObjectBed, g.iconForObject),
+	}
+	bed.displayName = "a bed"
+	bed.SetWalkable(false)
+	bed.SetHidden(false)
+	bed.SetTransparent(true)
+
+	for _, field := range rec {
+		switch strings.ToLower(field.Name) {
+		case "name":
+			bed.internalName = field.Value
+		case "description":
+			bed.displayName = field.Value
+		case "position":
+			bed.position, _ = geometry.NewPointFromEncodedString(field.Value)
+		}
+	}
+
+	bed.InitWithGameState(g)
+	return bed
+}
+
+func (b *Bed) InitWithGameState(g *GameState) {
+	b.iconForObject = g.iconForObject
+}
+
+func (b *Bed) AppendContextActions(items []foundation.MenuItem, g *GameState) []foundation.MenuItem {
+	if g.currentMap().MoveDistance(g.Player.Position(), b.Position()) > 1 {
+		return items
+	}
+	return append(items, foundation.MenuItem{
+		Name:       "Sleep",
+		Action:     g.OpenSleepMenu,
+		CloseMenus: false,
+	})
+}
+
+func (b *Bed) ToRecord() recfile.Record {
+	rec := recfile.Record{
+		{Name: "category", Value: b.category.LowerString()},
+		{Name: "position", Value: b.position.Encode()},
+		{Name: "description", Value: b.displayName},
+	}
+	if b.internalName != "" {
+		rec = append(rec, recfile.Field{Name: "name", Value: b.internalName})
+	}
+	return rec
+}
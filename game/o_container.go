@@ -9,6 +9,7 @@ import (
 	"github.com/memmaker/go/recfile"
 	"github.com/memmaker/go/textiles"
 	"strings"
+	"time"
 )
 
 type Container struct {
@@ -22,6 +23,10 @@ type Container struct {
 
 	flagRemovalOf string
 	flagCall      func()
+
+	restockItems    []string
+	restockInterval time.Duration
+	sinceRestock    time.Duration
 }
 
 func (b *Container) GobEncode() ([]byte, error) {
@@ -40,6 +45,18 @@ func (b *Container) GobEncode() ([]byte, error) {
 		return nil, err
 	}
 
+	if err := enc.Encode(b.restockItems); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(b.restockInterval); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(b.sinceRestock); err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -59,6 +76,18 @@ func (b *Container) GobDecode(data []byte) error {
 		return err
 	}
 
+	if err := dec.Decode(&b.restockItems); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&b.restockInterval); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&b.sinceRestock); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -76,12 +105,16 @@ func (b *Container) Icon() textiles.TextIcon {
 	return b.iconForObject(b.GetCategory().LowerString())
 }
 func (b *Container) OnBump(actor *Actor) {
-	if b.isPlayer(actor) {
+	if b.isPlayer(actor) && !b.IsHidden() {
 		b.show()
 		b.isKnown = true
 	}
 }
 
+func (b *Container) IsFlammable() bool {
+	return true
+}
+
 func (b *Container) RemoveItem(item foundation.Item) {
 	for i, containedItem := range b.containedItems {
 		if containedItem == item {
@@ -119,6 +152,7 @@ func (g *GameState) NewContainer(rec recfile.Record) Object {
 	container.SetWalkable(false)
 	container.SetHidden(false)
 	container.SetTransparent(true)
+	var itemNames []string
 	for _, field := range rec {
 		switch strings.ToLower(field.Name) {
 		case "name":
@@ -131,17 +165,46 @@ func (g *GameState) NewContainer(rec recfile.Record) Object {
 			item := g.NewItemFromString(field.Value)
 			if item != nil {
 				container.AddItem(item)
+				itemNames = append(itemNames, field.Value)
 			}
+		case "restock":
+			container.restockInterval = time.Duration(field.AsInt()) * time.Hour
 		case "flag_removal_of":
 			container.flagRemovalOf = field.Value
 		case "lockflag":
 			container.lockFlag = field.Value
+		case "hidden":
+			container.SetHidden(field.AsBool())
+		case "discoverydifficulty":
+			container.SetDiscoveryDifficulty(foundation.DifficultyFromString(field.Value))
 		}
 	}
+	if container.restockInterval > 0 {
+		container.restockItems = itemNames
+	}
 	container.InitWithGameState(g)
 	return container
 }
 
+// restock refills the missing items of the original stock, once the restock interval in hours has passed.
+// It's only called while the player is away or resting.
+func (b *Container) restock(g *GameState, elapsed time.Duration) {
+	if b.restockInterval <= 0 {
+		return
+	}
+	b.sinceRestock += elapsed
+	if b.sinceRestock < b.restockInterval {
+		return
+	}
+	b.sinceRestock %= b.restockInterval
+	for _, itemName := range b.restockItems {
+		item := g.NewItemFromString(itemName)
+		if item != nil && !b.HasItemsWithName(item.InternalName(), item.StackSize()) {
+			b.AddItem(item)
+		}
+	}
+}
+
 func (b *Container) InitWithGameState(g *GameState) {
 	b.iconForObject = g.iconForObject
 	b.isPlayer = func(actor *Actor) bool { return actor == g.Player }
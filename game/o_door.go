@@ -18,6 +18,7 @@ type Door struct {
 	lockDiff              foundation.Difficulty
 	lockStrengthRemaining int
 	numberLock            []rune
+	accessTeam            string
 
 	hitpoints        int
 	damageThreshold  int
@@ -53,6 +54,7 @@ func (b *Door) InitWithGameState(g *GameState) {
 					if success {
 						b.category = foundation.ObjectClosedDoor
 						g.msg(foundation.Msg("You picked the lock deftly"))
+						g.learnByDoing(special.Mechanics, learnGainTask, b.lockDiff.GetRollModifier())
 						g.ui.PlayCue("world/PICKKEYS")
 					} else {
 						g.msg(foundation.Msg("You failed to pick the lock"))
@@ -132,6 +134,10 @@ func (b *Door) GobEncode() ([]byte, error) {
 		return nil, err
 	}
 
+	if err := enc.Encode(b.accessTeam); err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -168,9 +174,16 @@ func (b *Door) GobDecode(data []byte) error {
 		return err
 	}
 
+	if err := dec.Decode(&b.accessTeam); err != nil {
+		return err
+	}
+
 	return nil
 }
 func (b *Door) IsTransparent() bool {
+	if b.IsHidden() {
+		return false
+	}
 	if b.isTransparent {
 		return true
 	}
@@ -184,12 +197,29 @@ func (b *Door) IsTransparent() bool {
 }
 
 func (b *Door) Icon() textiles.TextIcon {
+	if b.IsHidden() {
+		return b.iconForObject("secretdoor")
+	}
 	return b.iconForObject(b.GetCategory().LowerString())
 }
 func (b *Door) IsWalkable(actor *Actor) bool {
-	return b.GetCategory() != foundation.ObjectLockedDoor || (actor != nil && actor.HasKey(b.lockedFlag))
+	if b.IsHidden() {
+		return false
+	}
+	return b.GetCategory() != foundation.ObjectLockedDoor || (actor != nil && b.HasAccess(actor))
+}
+
+// HasAccess is true for actors carrying the key and for NPCs of the team the door belongs to.
+func (b *Door) HasAccess(actor *Actor) bool {
+	if b.lockedFlag != "" && actor.HasKey(b.lockedFlag) {
+		return true
+	}
+	return b.accessTeam != "" && actor.GetTeam() == b.accessTeam
 }
 func (b *Door) AppendContextActions(items []foundation.MenuItem, g *GameState) []foundation.MenuItem {
+	if b.IsHidden() {
+		return items
+	}
 	if b.GetCategory() == foundation.ObjectLockedDoor && g.Player.HasKey(b.lockedFlag) {
 		items = append(items, foundation.MenuItem{
 			Name: "Unlock",
@@ -240,6 +270,14 @@ func (b *Door) Unlock() {
 	b.category = foundation.ObjectClosedDoor
 }
 
+func (b *Door) Lock() {
+	if b.IsBroken() {
+		return
+	}
+	b.category = foundation.ObjectLockedDoor
+	b.updatePlayerFoV()
+}
+
 func (b *Door) Close() {
 	if b.IsBroken() {
 		return
@@ -304,6 +342,8 @@ func (g *GameState) NewDoor(rec recfile.Record) *Door {
 			door.displayName = field.Value
 		case "lockflag":
 			door.lockedFlag = field.Value
+		case "accessteam":
+			door.accessTeam = field.Value
 		case "numberlock":
 			door.numberLock = []rune(field.Value)
 		case "lockdifficulty":
@@ -318,6 +358,10 @@ func (g *GameState) NewDoor(rec recfile.Record) *Door {
 			door.audioCueBaseName = field.Value
 		case "istransparent":
 			door.SetTransparent(field.AsBool())
+		case "hidden":
+			door.SetHidden(field.AsBool())
+		case "discoverydifficulty":
+			door.SetDiscoveryDifficulty(foundation.DifficultyFromString(field.Value))
 		}
 	}
 
@@ -342,6 +386,10 @@ func (b *Door) OnDamage(dmg SourcedDamage) []foundation.Animation {
 }
 
 func (b *Door) Name() string {
+	if b.IsHidden() {
+		return "a wall"
+	}
+
 	if b.IsBroken() {
 		return fmt.Sprintf("%s (broken)", b.displayName)
 	}
@@ -373,7 +421,7 @@ func (b *Door) IsClosedButNotLocked() bool {
 }
 
 func (b *Door) OnBump(actor *Actor) {
-	if b.onBump != nil {
+	if b.onBump != nil && !b.IsHidden() {
 		b.onBump(actor)
 	}
 }
@@ -401,6 +449,10 @@ func (b *Door) ToRecord() recfile.Record {
 	if b.audioCueBaseName != "" {
 		rec = append(rec, recfile.Field{Name: "audiocue", Value: b.audioCueBaseName})
 	}
+	if b.IsHidden() {
+		rec = append(rec, recfile.Field{Name: "hidden", Value: recfile.BoolStr(true)})
+		rec = append(rec, recfile.Field{Name: "discoverydifficulty", Value: b.discoveryDiff.LowerString()})
+	}
 	return rec
 }
 
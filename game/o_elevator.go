@@ -13,6 +13,8 @@ import (
 type ElevatorButton struct {
 	Label     string
 	LevelName string
+	// LockFlag is an optional game flag, the floor can't be reached while it is set
+	LockFlag string
 }
 
 func (b ElevatorButton) HasValues() bool {
@@ -40,6 +42,16 @@ func (b *Elevator) InitWithGameState(g *GameState) {
 		var elevatorActions = make([]foundation.MenuItem, len(b.levels))
 		for i, l := range b.levels {
 			level := l
+			if level.LockFlag != "" && g.gameFlags.HasFlag(level.LockFlag) {
+				elevatorActions[i] = foundation.MenuItem{
+					Name: level.Label + " (locked)",
+					Action: func() {
+						g.msg(foundation.Msg("The button doesn't respond"))
+					},
+					CloseMenus: true,
+				}
+				continue
+			}
 			elevatorActions[i] = foundation.MenuItem{
 				Name: level.Label,
 				Action: func() {
@@ -110,6 +122,10 @@ func (b *Elevator) GetIdentifier() string {
 func (b *Elevator) SetLockedByFlag(flag string) {
 	b.lockedFlag = flag
 }
+
+func (b *Elevator) GetLevels() []ElevatorButton {
+	return b.levels
+}
 func (g *GameState) NewElevator(rec recfile.Record) *Elevator {
 	identifier, description, pos, levels := parseElevatorRecord(rec)
 
@@ -158,6 +174,10 @@ func parseElevatorRecord(rec recfile.Record) (string, string, geometry.Point, []
 				levels = append(levels, currentButton)
 				currentButton = ElevatorButton{}
 			}
+		case "floorlockflag":
+			if len(levels) > 0 {
+				levels[len(levels)-1].LockFlag = field.Value
+			}
 		}
 	}
 
@@ -185,6 +205,9 @@ func (b *Elevator) ToRecord() recfile.Record {
 	for _, level := range b.levels {
 		rec = append(rec, recfile.Field{Name: "floordescription", Value: level.Label})
 		rec = append(rec, recfile.Field{Name: "floortarget", Value: level.LevelName})
+		if level.LockFlag != "" {
+			rec = append(rec, recfile.Field{Name: "floorlockflag", Value: level.LockFlag})
+		}
 	}
 	return rec
 }
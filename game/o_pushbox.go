@@ -111,3 +111,7 @@ func (b *PushBox) OnBump(actor *Actor) {
 func (b *PushBox) OnDamage(dmg SourcedDamage) []foundation.Animation {
 	return b.onDamage(dmg)
 }
+
+func (b *PushBox) IsFlammable() bool {
+	return true
+}
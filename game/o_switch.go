@@ -0,0 +1,204 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"bytes"
+	"encoding/gob"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"github.com/memmaker/go/textiles"
+	"strings"
+)
+
+// Switch is a lever or button on the map.
+// Flipping it sets or clears a flag and opens or closes the linked doors.
+type Switch struct {
+	*BaseObject
+	isOn        bool
+	flagName    string
+	linkedDoors []string
+
+	isPlayer func(actor *Actor) bool
+	onToggle func()
+}
+
+func (s *Switch) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := s.BaseObject.gobEncode(enc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(s.isOn); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(s.flagName); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(s.linkedDoors); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *Switch) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	s.BaseObject = &BaseObject{}
+
+	if err := s.BaseObject.gobDecode(dec); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&s.isOn); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&s.flagName); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&s.linkedDoors); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *GameState) NewSwitch(rec recfile.Record) *Switch {
+	lever := &Switch{
+		BaseObject: NewObject(foundation.ObjectSwitch, g.iconForObject),
+	}
+	lever.displayName = "a switch"
+	lever.SetWalkable(false)
+	lever.SetHidden(false)
+	lever.SetTransparent(true)
+
+	for _, field := range rec {
+		switch strings.ToLower(field.Name) {
+		case "name":
+			lever.internalName = field.Value
+		case "description":
+			lever.displayName = field.Value
+		case "position":
+			lever.position, _ = geometry.NewPointFromEncodedString(field.Value)
+		case "flag":
+			lever.flagName = field.Value
+		case "door":
+			lever.linkedDoors = append(lever.linkedDoors, field.Value)
+		case "ison":
+			lever.isOn = field.AsBool()
+		case "hidden":
+			lever.SetHidden(field.AsBool())
+		case "discoverydifficulty":
+			lever.SetDiscoveryDifficulty(foundation.DifficultyFromString(field.Value))
+		}
+	}
+
+	lever.InitWithGameState(g)
+	return lever
+}
+
+func (s *Switch) InitWithGameState(g *GameState) {
+	s.iconForObject = g.iconForObject
+	s.isPlayer = func(actor *Actor) bool { return actor == g.Player }
+	s.onToggle = func() {
+		if s.isOn {
+			g.msg(foundation.HiLite("You switch %s on.", s.displayName))
+		} else {
+			g.msg(foundation.HiLite("You switch %s off.", s.displayName))
+		}
+
+		if s.flagName != "" {
+			if s.isOn {
+				g.gameFlags.SetFlag(s.flagName)
+			} else {
+				g.gameFlags.ClearFlag(s.flagName)
+			}
+		}
+
+		for _, doorName := range s.linkedDoors {
+			door, exists := g.TryGetDoorByName(doorName)
+			if !exists {
+				continue
+			}
+			if s.isOn {
+				door.Unlock()
+				door.Open()
+			} else {
+				door.Close()
+			}
+		}
+	}
+}
+
+func (s *Switch) Icon() textiles.TextIcon {
+	if s.isOn {
+		return s.iconForObject("switchon")
+	}
+	return s.iconForObject("switchoff")
+}
+
+func (s *Switch) Name() string {
+	if s.isOn {
+		return s.displayName + " (on)"
+	}
+	return s.displayName + " (off)"
+}
+
+func (s *Switch) IsOn() bool {
+	return s.isOn
+}
+
+func (s *Switch) Toggle() {
+	s.isOn = !s.isOn
+	if s.onToggle != nil {
+		s.onToggle()
+	}
+}
+
+func (s *Switch) OnBump(actor *Actor) {
+	if s.isPlayer(actor) && !s.IsHidden() {
+		s.Toggle()
+	}
+}
+
+func (s *Switch) AppendContextActions(items []foundation.MenuItem, g *GameState) []foundation.MenuItem {
+	if s.IsHidden() {
+		return items
+	}
+	return append(items, foundation.MenuItem{
+		Name:       "Flip",
+		Action:     s.Toggle,
+		CloseMenus: true,
+	})
+}
+
+func (s *Switch) ToRecord() recfile.Record {
+	rec := recfile.Record{
+		{Name: "category", Value: s.category.String()},
+		{Name: "position", Value: s.position.Encode()},
+		{Name: "description", Value: s.displayName},
+	}
+	if s.internalName != "" {
+		rec = append(rec, recfile.Field{Name: "name", Value: s.internalName})
+	}
+	if s.flagName != "" {
+		rec = append(rec, recfile.Field{Name: "flag", Value: s.flagName})
+	}
+	for _, doorName := range s.linkedDoors {
+		rec = append(rec, recfile.Field{Name: "door", Value: doorName})
+	}
+	if s.isOn {
+		rec = append(rec, recfile.Field{Name: "ison", Value: recfile.BoolStr(true)})
+	}
+	if s.IsHidden() {
+		rec = append(rec, recfile.Field{Name: "hidden", Value: recfile.BoolStr(true)})
+		rec = append(rec, recfile.Field{Name: "discoverydifficulty", Value: s.discoveryDiff.LowerString()})
+	}
+	return rec
+}
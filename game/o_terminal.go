@@ -9,11 +9,32 @@ import (
 	"strings"
 )
 
+type TerminalMessage struct {
+	Subject string
+	Text    string
+}
+
+// Terminal either starts a dialogue or, if it declares any capabilities,
+// opens a command line that controls the doors, lights, alarms, turrets and elevators linked to it.
 type Terminal struct {
 	*BaseObject
 	isPlayer          func(*Actor) bool
 	startDialogue     func()
+	startSession      func()
 	declareAsTerminal bool
+
+	title        string
+	messages     []TerminalMessage
+	doors        []string
+	lights       []geometry.Point
+	alarms       []string
+	turrets      []string
+	elevators    []string
+	passwordItem string
+	accessDiff   foundation.Difficulty
+	needsAccess  bool
+	hasAccess    bool
+	isLockedOut  bool
 }
 
 func (t *Terminal) GobEncode() ([]byte, error) {
@@ -28,6 +49,54 @@ func (t *Terminal) GobEncode() ([]byte, error) {
 		return nil, err
 	}
 
+	if err := enc.Encode(t.title); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.messages); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.doors); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.lights); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.alarms); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.turrets); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.elevators); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.passwordItem); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.accessDiff); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.needsAccess); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.hasAccess); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.isLockedOut); err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -43,11 +112,63 @@ func (t *Terminal) GobDecode(data []byte) error {
 		return err
 	}
 
+	if err := dec.Decode(&t.title); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.messages); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.doors); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.lights); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.alarms); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.turrets); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.elevators); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.passwordItem); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.accessDiff); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.needsAccess); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.hasAccess); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.isLockedOut); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func (g *GameState) NewTerminal(rec recfile.Record) *Terminal {
-	terminal := &Terminal{BaseObject: NewObject(foundation.ObjectTerminal, g.iconForObject)}
+	terminal := &Terminal{
+		BaseObject: NewObject(foundation.ObjectTerminal, g.iconForObject),
+		title:      "Vault-Tec Termlink",
+		accessDiff: foundation.Medium,
+	}
 	terminal.SetWalkable(false)
 	terminal.SetHidden(false)
 	terminal.SetTransparent(true)
@@ -70,6 +191,28 @@ func (g *GameState) NewTerminal(rec recfile.Record) *Terminal {
 			}
 		case "declared_as_terminal":
 			terminal.declareAsTerminal = recfile.StrBool(field.Value)
+		case "title":
+			terminal.title = field.Value
+		case "message":
+			subject, text, _ := strings.Cut(field.Value, "\n")
+			terminal.messages = append(terminal.messages, TerminalMessage{Subject: subject, Text: text})
+		case "door":
+			terminal.doors = append(terminal.doors, field.Value)
+		case "light":
+			lightPos, _ := geometry.NewPointFromEncodedString(field.Value)
+			terminal.lights = append(terminal.lights, lightPos)
+		case "alarm":
+			terminal.alarms = append(terminal.alarms, field.Value)
+		case "turret":
+			terminal.turrets = append(terminal.turrets, field.Value)
+		case "elevator":
+			terminal.elevators = append(terminal.elevators, field.Value)
+		case "password":
+			terminal.passwordItem = field.Value
+			terminal.needsAccess = true
+		case "accessdifficulty":
+			terminal.accessDiff = foundation.DifficultyFromString(field.Value)
+			terminal.needsAccess = true
 		}
 	}
 	terminal.InitWithGameState(g)
@@ -80,16 +223,31 @@ func (t *Terminal) InitWithGameState(g *GameState) {
 	t.iconForObject = g.iconForObject
 	t.isPlayer = func(actor *Actor) bool { return actor == g.Player }
 	t.startDialogue = func() { g.StartDialogue(t.internalName, t, t.declareAsTerminal) }
+	t.startSession = func() { g.openTerminalSession(t) }
 }
 
 func (t *Terminal) OnBump(actor *Actor) {
-	if t.isPlayer(actor) {
+	if !t.isPlayer(actor) {
+		return
+	}
+	if t.HasOperatingSystem() {
+		t.startSession()
+	} else {
 		t.startDialogue()
 	}
 }
 
+// HasOperatingSystem is true for terminals that declare capabilities instead of only a dialogue.
+func (t *Terminal) HasOperatingSystem() bool {
+	return len(t.messages) > 0 || len(t.doors) > 0 || len(t.lights) > 0 || len(t.alarms) > 0 || len(t.turrets) > 0 || len(t.elevators) > 0
+}
+
+func (t *Terminal) HasAccess() bool {
+	return !t.needsAccess || t.hasAccess
+}
+
 func (t *Terminal) ToRecord() recfile.Record {
-	return recfile.Record{
+	rec := recfile.Record{
 		{Name: "category", Value: t.category.String()},
 		{Name: "description", Value: t.displayName},
 		{Name: "dialogue", Value: t.internalName},
@@ -99,4 +257,33 @@ func (t *Terminal) ToRecord() recfile.Record {
 		{Name: "bg", Value: recfile.RGBStr(t.customIcon.Bg)},
 		{Name: "declared_as_terminal", Value: recfile.BoolStr(t.declareAsTerminal)},
 	}
+	if !t.HasOperatingSystem() {
+		return rec
+	}
+	rec = append(rec, recfile.Field{Name: "title", Value: t.title})
+	for _, message := range t.messages {
+		rec = append(rec, recfile.Field{Name: "message", Value: message.Subject + "\n" + message.Text})
+	}
+	for _, door := range t.doors {
+		rec = append(rec, recfile.Field{Name: "door", Value: door})
+	}
+	for _, light := range t.lights {
+		rec = append(rec, recfile.Field{Name: "light", Value: light.Encode()})
+	}
+	for _, alarm := range t.alarms {
+		rec = append(rec, recfile.Field{Name: "alarm", Value: alarm})
+	}
+	for _, turret := range t.turrets {
+		rec = append(rec, recfile.Field{Name: "turret", Value: turret})
+	}
+	for _, elevator := range t.elevators {
+		rec = append(rec, recfile.Field{Name: "elevator", Value: elevator})
+	}
+	if t.passwordItem != "" {
+		rec = append(rec, recfile.Field{Name: "password", Value: t.passwordItem})
+	}
+	if t.needsAccess {
+		rec = append(rec, recfile.Field{Name: "accessdifficulty", Value: t.accessDiff.LowerString()})
+	}
+	return rec
 }
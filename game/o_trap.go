@@ -2,33 +2,100 @@ package game
 
 import (
 	"RogueUI/foundation"
+	"RogueUI/gridmap"
+	"RogueUI/special"
 	"bytes"
 	"encoding/gob"
+	"fmt"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/geometry"
 	"github.com/memmaker/go/recfile"
+	"strings"
 )
 
 type Trap struct {
 	*BaseObject
 	isLoaded bool
-	trigger  func() []foundation.Animation
+
+	zapEffectName  string
+	damage         int
+	damageInterval fxtools.Interval
+	radius         int
+	intensity      int
+
+	disarmDiff     foundation.Difficulty
+	components     []string
+	wireGroup      string
+	placedByPlayer bool
+
+	trigger func(actor *Actor) []foundation.Animation
+	disarm  func()
 }
 
 func (t *Trap) InitWithGameState(g *GameState) {
 	t.iconForObject = g.iconForObject
-	t.trigger = func() []foundation.Animation {
-		if t.isLoaded {
-			t.isLoaded = false
-			zapEffect := ZapEffectFromName(t.category.ZapEffect())
-			return zapEffect(g, nil, t.Position(), foundation.Params{})
+	t.trigger = func(actor *Actor) []foundation.Animation {
+		if !t.isLoaded {
+			return nil
+		}
+		// the player and their allies know where the player's own mines are
+		if t.placedByPlayer && actor != nil && (actor == g.Player || !actor.IsEnemyOf(g.Player)) {
+			return nil
+		}
+		zapEffect := ZapEffectFromName(t.ZapEffect())
+		if zapEffect == nil {
+			return nil
 		}
-		return nil
+		for _, linked := range g.trapsInGroup(t) {
+			linked.isLoaded = false
+			linked.SetHidden(false)
+		}
+		if actor == g.Player {
+			g.msg(foundation.HiLite("You triggered %s!", t.Name()))
+		}
+		var zapper *Actor
+		if t.placedByPlayer {
+			zapper = g.Player
+		}
+		return zapEffect(g, zapper, t.Position(), t.effectParameters())
+	}
+	t.disarm = func() {
+		g.playerDisarmTrap(t)
 	}
 }
+
 func (t *Trap) ToRecord() recfile.Record {
-	return recfile.Record{
+	rec := recfile.Record{
 		{Name: "category", Value: t.category.String()},
 		{Name: "position", Value: t.position.Encode()},
 	}
+	if t.internalName != "" {
+		rec = append(rec, recfile.Field{Name: "name", Value: t.internalName})
+	}
+	if t.displayName != "" {
+		rec = append(rec, recfile.Field{Name: "description", Value: t.displayName})
+	}
+	if t.zapEffectName != "" {
+		rec = append(rec, recfile.Field{Name: "zap_effect", Value: t.zapEffectName})
+	}
+	if t.damage > 0 {
+		rec = append(rec, recfile.Field{Name: "effect_damage", Value: recfile.IntStr(t.damage)})
+	}
+	if t.radius > 0 {
+		rec = append(rec, recfile.Field{Name: "effect_radius", Value: recfile.IntStr(t.radius)})
+	}
+	if t.intensity > 0 {
+		rec = append(rec, recfile.Field{Name: "effect_intensity", Value: recfile.IntStr(t.intensity)})
+	}
+	rec = append(rec, recfile.Field{Name: "discoverydifficulty", Value: t.discoveryDiff.LowerString()})
+	rec = append(rec, recfile.Field{Name: "disarmdifficulty", Value: t.disarmDiff.LowerString()})
+	for _, component := range t.components {
+		rec = append(rec, recfile.Field{Name: "component", Value: component})
+	}
+	if !t.IsHidden() {
+		rec = append(rec, recfile.Field{Name: "hidden", Value: recfile.BoolStr(false)})
+	}
+	return rec
 }
 
 func (t *Trap) GobEncode() ([]byte, error) {
@@ -43,6 +110,42 @@ func (t *Trap) GobEncode() ([]byte, error) {
 		return nil, err
 	}
 
+	if err := enc.Encode(t.zapEffectName); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.damage); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.damageInterval); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.radius); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.intensity); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.disarmDiff); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.components); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.wireGroup); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(t.placedByPlayer); err != nil {
+		return nil, err
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -58,6 +161,42 @@ func (t *Trap) GobDecode(data []byte) error {
 		return err
 	}
 
+	if err := dec.Decode(&t.zapEffectName); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.damage); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.damageInterval); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.radius); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.intensity); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.disarmDiff); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.components); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.wireGroup); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&t.placedByPlayer); err != nil {
+		return err
+	}
+
 	return nil
 }
 func (g *GameState) NewTrap(trapType foundation.ObjectCategory) *Trap {
@@ -65,15 +204,262 @@ func (g *GameState) NewTrap(trapType foundation.ObjectCategory) *Trap {
 
 	trap.SetHidden(true)
 	trap.SetWalkable(true)
+	trap.SetTransparent(true)
+	trap.SetDiscoveryDifficulty(foundation.Medium)
+	trap.disarmDiff = foundation.Medium
 
 	trap.InitWithGameState(g)
 	return trap
 }
 
+// NewTrapFromRecord creates a trap defined in objects.rec.
+// Traps with an "end" position are wires spanning all tiles between both positions,
+// the additional segments are added to the map directly.
+func (g *GameState) NewTrapFromRecord(rec recfile.Record, trapType foundation.ObjectCategory, newMap *gridmap.GridMap[*Actor, foundation.Item, Object]) *Trap {
+	trap := g.NewTrap(trapType)
+
+	var endPos geometry.Point
+	var hasEnd bool
+	for _, field := range rec {
+		switch strings.ToLower(field.Name) {
+		case "name":
+			trap.internalName = field.Value
+		case "description":
+			trap.displayName = field.Value
+		case "position":
+			trap.position, _ = geometry.NewPointFromEncodedString(field.Value)
+		case "end":
+			endPos, _ = geometry.NewPointFromEncodedString(field.Value)
+			hasEnd = true
+		case "zap_effect":
+			if zapEffectExists(field.Value) {
+				trap.zapEffectName = field.Value
+			} else {
+				panic("Invalid zap effect: " + field.Value)
+			}
+		case "effect_damage":
+			trap.damage = field.AsInt()
+		case "effect_damage_interval":
+			trap.damageInterval = fxtools.ParseInterval(field.Value)
+		case "effect_radius":
+			trap.radius = field.AsInt()
+		case "effect_intensity":
+			trap.intensity = field.AsInt()
+		case "hidden":
+			trap.SetHidden(field.AsBool())
+		case "discoverydifficulty":
+			trap.SetDiscoveryDifficulty(foundation.DifficultyFromString(field.Value))
+		case "disarmdifficulty":
+			trap.disarmDiff = foundation.DifficultyFromString(field.Value)
+		case "component":
+			trap.components = append(trap.components, field.Value)
+		}
+	}
+
+	if hasEnd && endPos != trap.position {
+		trap.wireGroup = trap.SecretID()
+		for _, segmentPos := range geometry.BresenhamLine(trap.position, endPos, func(x, y int) bool { return true }) {
+			if segmentPos == trap.position {
+				continue
+			}
+			segment := trap.copyAsSegment(g)
+			segment.position = segmentPos
+			newMap.AddObject(segment, segmentPos)
+		}
+	}
+	return trap
+}
+
+func (t *Trap) copyAsSegment(g *GameState) *Trap {
+	segment := g.NewTrap(t.category)
+	segment.displayName = t.displayName
+	segment.isHidden = t.isHidden
+	segment.discoveryDiff = t.discoveryDiff
+	segment.zapEffectName = t.zapEffectName
+	segment.damage = t.damage
+	segment.damageInterval = t.damageInterval
+	segment.radius = t.radius
+	segment.intensity = t.intensity
+	segment.disarmDiff = t.disarmDiff
+	segment.components = t.components
+	segment.wireGroup = t.wireGroup
+	segment.placedByPlayer = t.placedByPlayer
+	return segment
+}
+
+func (t *Trap) ZapEffect() string {
+	if t.zapEffectName != "" {
+		return t.zapEffectName
+	}
+	return t.category.ZapEffect()
+}
+
+func (t *Trap) effectParameters() foundation.Params {
+	params := foundation.Params{}
+	if t.damage > 0 {
+		params["damage"] = t.damage
+	}
+	if t.damageInterval != (fxtools.Interval{}) {
+		params["damage_interval"] = t.damageInterval
+	}
+	if t.radius > 0 {
+		params["radius"] = t.radius
+	}
+	if t.intensity > 0 {
+		params["intensity"] = t.intensity
+	}
+	return params
+}
+
+func (t *Trap) IsLoaded() bool {
+	return t.isLoaded
+}
+
+func (t *Trap) Name() string {
+	if t.isLoaded {
+		return t.BaseObject.Name()
+	}
+	return fmt.Sprintf("%s (sprung)", t.BaseObject.Name())
+}
+
 func (t *Trap) OnDamage(damage SourcedDamage) []foundation.Animation {
-	return t.trigger()
+	return t.trigger(nil)
 }
 
 func (t *Trap) OnWalkOver(actor *Actor) []foundation.Animation {
-	return t.trigger()
+	return t.trigger(actor)
+}
+
+func (t *Trap) AppendContextActions(items []foundation.MenuItem, g *GameState) []foundation.MenuItem {
+	if t.IsHidden() || !t.isLoaded {
+		return items
+	}
+	return append(items, foundation.MenuItem{
+		Name:       "Disarm",
+		Action:     t.disarm,
+		CloseMenus: true,
+	})
+}
+
+// trapsInGroup returns all segments of a wire, or just the trap itself.
+func (g *GameState) trapsInGroup(trap *Trap) []*Trap {
+	if trap.wireGroup == "" {
+		return []*Trap{trap}
+	}
+	var group []*Trap
+	for _, obj := range g.currentMap().Objects() {
+		if other, isTrap := obj.(*Trap); isTrap && other.wireGroup == trap.wireGroup {
+			group = append(group, other)
+		}
+	}
+	return group
+}
+
+func (g *GameState) playerDisarmTrap(trap *Trap) {
+	rollResult := g.Player.GetCharSheet().SkillRoll(special.Mechanics, trap.disarmDiff.GetRollModifier())
+	if rollResult.Success {
+		g.msg(foundation.HiLite("You disarmed %s.", trap.Name()))
+		for _, segment := range g.trapsInGroup(trap) {
+			g.currentMap().RemoveObject(segment)
+		}
+		g.salvageComponents(trap.components, trap.Position())
+	} else if rollResult.Crit {
+		g.msg(foundation.HiLite("You fumbled with %s and set it off!", trap.Name()))
+		g.ui.AddAnimations(trap.trigger(nil))
+	} else {
+		g.msg(foundation.HiLite("You failed to disarm %s.", trap.Name()))
+	}
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+func (g *GameState) salvageComponents(components []string, pos geometry.Point) {
+	for _, componentName := range components {
+		component := g.NewItemFromString(componentName)
+		if component == nil {
+			continue
+		}
+		if g.Player.GetInventory().IsFull() {
+			g.addItemToMap(component, pos)
+		} else {
+			g.Player.GetInventory().AddItem(component)
+		}
+		g.msg(foundation.HiLite("You salvaged %s.", component.Name()))
+	}
+}
+
+const maxTripwireLength = 3
+
+func (g *GameState) startPlaceTrapItem(item foundation.Item) {
+	g.ui.SelectDirection(func(direction geometry.CompassDirection) {
+		g.playerPlaceTrapItem(item, direction)
+	})
+}
+
+// playerPlaceTrapItem turns a mine or tripwire charge from the inventory into a trap next to the player.
+// Tripwires are stretched in the chosen direction.
+func (g *GameState) playerPlaceTrapItem(item foundation.Item, direction geometry.CompassDirection) {
+	placePos := g.Player.Position().Add(direction.ToPoint())
+	if !g.canPlaceTrapAt(placePos) {
+		g.msg(foundation.Msg("You cannot place that there"))
+		return
+	}
+
+	removed := g.Player.GetInventory().RemoveItemsByNameAndCount(item.InternalName(), 1)
+	if len(removed) == 0 {
+		return
+	}
+	placedItem := removed[0]
+
+	trapType := foundation.ObjectPressurePlate
+	if placedItem.HasTag(foundation.TagTripwire) {
+		trapType = foundation.ObjectTripwire
+	}
+
+	trap := g.NewTrap(trapType)
+	trap.SetHidden(false)
+	trap.SetDiscoveryDifficulty(foundation.Hard)
+	trap.disarmDiff = foundation.VeryEasy
+	trap.displayName = placedItem.Name()
+	trap.zapEffectName = placedItem.ZapEffect()
+	trap.placedByPlayer = true
+	trap.components = []string{placedItem.InternalName()}
+
+	params := placedItem.GetEffectParameters()
+	if params.Has("damage") {
+		trap.damage = params.GetInt("damage")
+	}
+	if params.Has("damage_interval") {
+		trap.damageInterval = params.GetInterval("damage_interval")
+	}
+	if params.Has("radius") {
+		trap.radius = params.GetInt("radius")
+	}
+	if params.Has("intensity") {
+		trap.intensity = params.GetInt("intensity")
+	}
+
+	g.currentMap().AddObject(trap, placePos)
+
+	if trapType == foundation.ObjectTripwire {
+		trap.wireGroup = fmt.Sprintf("%s_%s", placedItem.InternalName(), placePos.Encode())
+		segmentPos := placePos
+		for i := 1; i < maxTripwireLength; i++ {
+			segmentPos = segmentPos.Add(direction.ToPoint())
+			if !g.canPlaceTrapAt(segmentPos) {
+				break
+			}
+			segment := trap.copyAsSegment(g)
+			g.currentMap().AddObject(segment, segmentPos)
+		}
+	}
+
+	g.msg(foundation.HiLite("You place %s.", placedItem.Name()))
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+func (g *GameState) canPlaceTrapAt(pos geometry.Point) bool {
+	return g.currentMap().Contains(pos) &&
+		g.currentMap().IsTileWalkable(pos) &&
+		!g.currentMap().IsObjectAt(pos) &&
+		!g.currentMap().IsActorAt(pos)
 }
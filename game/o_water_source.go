@@ -0,0 +1,109 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"bytes"
+	"encoding/gob"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"strings"
+)
+
+// WaterSource is a sink, fountain or water pump. Irradiated water quenches the thirst, but hurts.
+type WaterSource struct {
+	*BaseObject
+	isIrradiated bool
+}
+
+func (w *WaterSource) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+
+	if err := w.BaseObject.gobEncode(enc); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(w.isIrradiated); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (w *WaterSource) GobDecode(data []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(data))
+
+	w.BaseObject = &BaseObject{}
+
+	if err := w.BaseObject.gobDecode(dec); err != nil {
+		return err
+	}
+
+	if err := dec.Decode(&w.isIrradiated); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (g *GameState) NewWaterSource(rec recfile.Record) *WaterSource {
+	source := &WaterSource{
+		BaseObject: NewObject(foundation.ObjectWaterSource, g.iconForObject),
+	}
+	source.displayName = "a water pump"
+	source.SetWalkable(false)
+	source.SetHidden(false)
+	source.SetTransparent(true)
+
+	for _, field := range rec {
+		switch strings.ToLower(field.Name) {
+		case "name":
+			source.internalName = field.Value
+		case "description":
+			source.displayName = field.Value
+		case "position":
+			source.position, _ = geometry.NewPointFromEncodedString(field.Value)
+		case "irradiated":
+			source.isIrradiated = field.AsBool()
+		}
+	}
+
+	source.InitWithGameState(g)
+	return source
+}
+
+func (w *WaterSource) InitWithGameState(g *GameState) {
+	w.iconForObject = g.iconForObject
+}
+
+func (w *WaterSource) IsIrradiated() bool {
+	return w.isIrradiated
+}
+
+func (w *WaterSource) AppendContextActions(items []foundation.MenuItem, g *GameState) []foundation.MenuItem {
+	if g.currentMap().MoveDistance(g.Player.Position(), w.Position()) > 1 {
+		return items
+	}
+	return append(items, foundation.MenuItem{
+		Name: "Drink",
+		Action: func() {
+			g.playerDrink(w.isIrradiated)
+		},
+		CloseMenus: true,
+	})
+}
+
+func (w *WaterSource) ToRecord() recfile.Record {
+	rec := recfile.Record{
+		{Name: "category", Value: w.category.LowerString()},
+		{Name: "position", Value: w.position.Encode()},
+		{Name: "description", Value: w.displayName},
+	}
+	if w.internalName != "" {
+		rec = append(rec, recfile.Field{Name: "name", Value: w.internalName})
+	}
+	if w.isIrradiated {
+		rec = append(rec, recfile.Field{Name: "irradiated", Value: recfile.BoolStr(true)})
+	}
+	return rec
+}
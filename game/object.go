@@ -17,6 +17,10 @@ func init() {
 	gob.Register(&Elevator{})
 	gob.Register(&Container{})
 	gob.Register(&PushBox{})
+	gob.Register(&Switch{})
+	gob.Register(&AlarmPanel{})
+	gob.Register(&Bed{})
+	gob.Register(&WaterSource{})
 }
 
 type BaseObject struct {
@@ -32,6 +36,7 @@ type BaseObject struct {
 	useCustomIcon           bool
 	isAlive                 bool
 	isPassableForProjectile bool
+	discoveryDiff           foundation.Difficulty
 }
 
 func (b *BaseObject) gobEncode(enc *gob.Encoder) error {
@@ -79,6 +84,10 @@ func (b *BaseObject) gobEncode(enc *gob.Encoder) error {
 		return err
 	}
 
+	if err := enc.Encode(b.discoveryDiff); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -127,6 +136,10 @@ func (b *BaseObject) gobDecode(dec *gob.Decoder) error {
 		return err
 	}
 
+	if err := dec.Decode(&b.discoveryDiff); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -172,6 +185,10 @@ func (b *BaseObject) IsAlive() bool {
 	return b.isAlive
 }
 
+func (b *BaseObject) IsFlammable() bool {
+	return false
+}
+
 func (b *BaseObject) SetWalkable(isWalkable bool) {
 	b.isWalkable = isWalkable
 }
@@ -184,6 +201,23 @@ func (b *BaseObject) SetHidden(isHidden bool) {
 	b.isHidden = isHidden
 }
 
+func (b *BaseObject) GetDiscoveryDifficulty() foundation.Difficulty {
+	return b.discoveryDiff
+}
+
+func (b *BaseObject) SetDiscoveryDifficulty(difficulty foundation.Difficulty) {
+	b.discoveryDiff = difficulty
+}
+
+// SecretID identifies a hidden object for the FoundSecret() condition.
+// Objects without an internal name are identified by their position.
+func (b *BaseObject) SecretID() string {
+	if b.internalName != "" {
+		return b.internalName
+	}
+	return b.position.Encode()
+}
+
 func (b *BaseObject) Name() string {
 	if b.displayName != "" {
 		return b.displayName
@@ -229,10 +263,13 @@ type Object interface {
 	SetPosition(pos geometry.Point)
 	SetHidden(isHidden bool)
 	IsHidden() bool
+	GetDiscoveryDifficulty() foundation.Difficulty
+	SecretID() string
 	IsWalkable(actor *Actor) bool
 	IsTransparent() bool
 	IsPassableForProjectile() bool
 	IsAlive() bool
+	IsFlammable() bool
 	IsTrap() bool
 	OnDamage(dmg SourcedDamage) []foundation.Animation
 	OnWalkOver(actor *Actor) []foundation.Animation
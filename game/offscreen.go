@@ -0,0 +1,159 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/gridmap"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"strings"
+	"time"
+)
+
+// Only the current map is updated every turn, the other active maps are frozen. When the player returns
+// to a map, or when a lot of time passes at once, the elapsed time is applied in coarse steps without animations.
+const (
+	offscreenStepTurns = 30
+	offscreenMaxSteps  = 200
+)
+
+// leaveMap remembers when the player left the map, so it can be caught up on return.
+func (g *GameState) leaveMap(mapName string) {
+	g.mapLeftAt[mapName] = g.gameTime.Time
+}
+
+// catchUpMap applies the time that has passed since the player left the map.
+func (g *GameState) catchUpMap(gridMap *gridmap.GridMap[*Actor, foundation.Item, Object]) {
+	leftAt, wasVisited := g.mapLeftAt[gridMap.GetName()]
+	if !wasVisited {
+		return
+	}
+	delete(g.mapLeftAt, gridMap.GetName())
+	g.simulateMap(gridMap, g.gameTime.Time.Sub(leftAt))
+}
+
+// simulateMap is the coarse simulation of a map, the map has to be the current one, since the scripts depend on it.
+func (g *GameState) simulateMap(gridMap *gridmap.GridMap[*Actor, foundation.Item, Object], elapsed time.Duration) {
+	if g.isSimulatingMap {
+		return
+	}
+	g.isSimulatingMap = true
+	defer func() { g.isSimulatingMap = false }()
+
+	steps := min(int(elapsed.Hours()*turnsPerHour)/offscreenStepTurns, offscreenMaxSteps)
+	for step := 0; step < steps; step++ {
+		for _, actor := range gridMap.Actors() {
+			if actor == g.Player || !actor.IsAlive() {
+				continue
+			}
+			g.simulateActor(actor)
+		}
+		g.scriptRunner.CheckAndRunFrames(gridMap.GetName())
+	}
+
+	for _, object := range gridMap.Objects() {
+		if container, isContainer := object.(*Container); isContainer {
+			container.restock(g, elapsed)
+		}
+	}
+
+	if steps > 0 {
+		g.moveActorsToDestinations(gridMap, steps*offscreenStepTurns)
+	}
+}
+
+// simulateActor applies one step of time to an actor, turn by turn like on the current map.
+func (g *GameState) simulateActor(actor *Actor) {
+	for turn := 0; turn < offscreenStepTurns; turn++ {
+		g.applyTurnEffects(actor)
+	}
+}
+
+// moveActorsToDestinations puts the actors that were walking somewhere at their destination.
+// Idle actors with a patrol route walk it for the given number of turns.
+func (g *GameState) moveActorsToDestinations(gridMap *gridmap.GridMap[*Actor, foundation.Item, Object], turns int) {
+	for _, actor := range gridMap.Actors() {
+		if actor == g.Player || !actor.IsAlive() || actor.IsStationary() {
+			continue
+		}
+		if !actor.HasActiveGoal() {
+			if actor.patrolRoute != "" {
+				g.catchUpPatrol(gridMap, actor, turns)
+			}
+			continue
+		}
+		var destination geometry.Point
+		switch actor.activeGoal.Kind {
+		case GoalKindMoveToSpawn:
+			destination = actor.SpawnPosition
+		case GoalKindMoveToLocation:
+			destination = actor.activeGoal.Location
+		default:
+			continue
+		}
+		if destination != actor.Position() && !gridMap.IsActorAt(destination) {
+			gridMap.MoveActor(actor, destination)
+		}
+		if actor.Position() == destination {
+			actor.RemoveGoal()
+		}
+	}
+}
+
+// catchUpPatrol moves the actor along its patrol route and puts it at the last waypoint it would have reached.
+// Every leg takes as many turns as the distance to the waypoint, plus the wait at the waypoint.
+func (g *GameState) catchUpPatrol(gridMap *gridmap.GridMap[*Actor, foundation.Item, Object], actor *Actor, turns int) {
+	route, exists := gridMap.GetPatrolRoute(actor.patrolRoute)
+	if !exists || len(route.Waypoints) == 0 {
+		return
+	}
+	if actor.patrolIndex >= len(route.Waypoints) {
+		actor.patrolIndex = 0
+	}
+	position := actor.Position()
+	for {
+		waypoint := route.Waypoints[actor.patrolIndex]
+		legTurns := max(1, geometry.DistanceChebyshev(position, waypoint.Position)+waypoint.WaitTurns)
+		if legTurns > turns {
+			break
+		}
+		turns -= legTurns
+		position = waypoint.Position
+		if waypoint.HasFacing {
+			actor.SetFacing(waypoint.Facing)
+		}
+		actor.patrolIndex, actor.patrolDirection = route.NextWaypoint(actor.patrolIndex, actor.patrolDirection)
+	}
+	actor.patrolWait = 0
+	if position != actor.Position() && !gridMap.IsActorAt(position) {
+		gridMap.MoveActor(actor, position)
+	}
+}
+
+func (g *GameState) mapLeftAtToRecords() []recfile.Record {
+	var recs []recfile.Record
+	for mapName, leftAt := range g.mapLeftAt {
+		recs = append(recs, recfile.Record{
+			recfile.Field{Name: "map", Value: mapName},
+			recfile.Field{Name: "time", Value: recfile.TimeStr(leftAt)},
+		})
+	}
+	return recs
+}
+
+func (g *GameState) mapLeftAtFromRecords(records []recfile.Record) map[string]time.Time {
+	result := make(map[string]time.Time)
+	for _, record := range records {
+		var mapName string
+		var leftAt time.Time
+		for _, field := range record {
+			switch strings.ToLower(field.Name) {
+			case "map":
+				mapName = field.Value
+			case "time":
+				leftAt = recfile.StrTime(field.Value)
+			}
+		}
+		result[mapName] = leftAt
+	}
+	return result
+}
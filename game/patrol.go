@@ -0,0 +1,114 @@
+package game
+
+import (
+	"RogueUI/gridmap"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/textiles"
+)
+
+var compassDirections = []geometry.CompassDirection{
+	geometry.North,
+	geometry.NorthEast,
+	geometry.East,
+	geometry.SouthEast,
+	geometry.South,
+	geometry.SouthWest,
+	geometry.West,
+	geometry.NorthWest,
+}
+
+// directionTowards returns the compass direction that points from one position towards the other.
+func directionTowards(from, to geometry.Point) geometry.CompassDirection {
+	step := geometry.Point{X: geometry.Sign(to.X - from.X), Y: geometry.Sign(to.Y - from.Y)}
+	for _, direction := range compassDirections {
+		if direction.ToPoint() == step {
+			return direction
+		}
+	}
+	return geometry.South
+}
+
+// idleMovement lets unaware NPCs walk their patrol route or return to their guard post.
+func (g *GameState) idleMovement(actor *Actor) (int, bool) {
+	if actor.patrolRoute != "" {
+		if route, exists := g.currentMap().GetPatrolRoute(actor.patrolRoute); exists && len(route.Waypoints) > 0 {
+			return g.patrol(actor, route), true
+		}
+	}
+
+	if actor.leashRadius > 0 && geometry.DistanceChebyshev(actor.Position(), actor.SpawnPosition) > actor.leashRadius {
+		actor.SetGoal(GoalMoveToSpawn())
+		return actor.ActOnGoal(g), true
+	}
+	return 0, false
+}
+
+func (g *GameState) patrol(actor *Actor, route gridmap.PatrolRoute) int {
+	if actor.patrolIndex >= len(route.Waypoints) {
+		actor.patrolIndex = 0
+	}
+	waypoint := route.Waypoints[actor.patrolIndex]
+	if actor.Position() == waypoint.Position {
+		if waypoint.HasFacing {
+			actor.SetFacing(waypoint.Facing)
+		}
+		if actor.patrolWait < waypoint.WaitTurns {
+			actor.patrolWait++
+			return actor.timeEnergy
+		}
+		actor.patrolWait = 0
+		actor.patrolIndex, actor.patrolDirection = route.NextWaypoint(actor.patrolIndex, actor.patrolDirection)
+		waypoint = route.Waypoints[actor.patrolIndex]
+		if actor.Position() == waypoint.Position {
+			return actor.timeEnergy
+		}
+	}
+
+	tuSpent := moveTowards(g, actor, waypoint.Position)
+	if actor.cannotFindPath() {
+		// blocked for good, try to reach the next waypoint instead
+		actor.patrolIndex, actor.patrolDirection = route.NextWaypoint(actor.patrolIndex, actor.patrolDirection)
+	}
+	return tuSpent
+}
+
+// playDoorSfxForNPC plays the door sounds for NPCs walking through doors the player can see.
+func (g *GameState) playDoorSfxForNPC(oldPos, newPos geometry.Point) {
+	if door, exists := g.TryGetDoorAt(newPos); exists && !door.IsOpen() && !door.IsBroken() && g.canPlayerSee(newPos) {
+		door.PlayOpenSfx()
+	}
+	if door, exists := g.TryGetDoorAt(oldPos); exists && !door.IsOpen() && !door.IsBroken() && g.canPlayerSee(oldPos) {
+		door.PlayCloseSfx()
+	}
+}
+
+// patrolRouteOverlay marks the paths between the waypoints of all routes on the current map.
+// Waypoints are shown as their index, the walked path as dots.
+func (g *GameState) patrolRouteOverlay() map[geometry.Point]textiles.TextIcon {
+	icons := make(map[geometry.Point]textiles.TextIcon)
+	pathIcon := textiles.TextIcon{Char: '·', Fg: g.palette.Get("yellow_1"), Bg: g.palette.Get("black")}
+	waypointIcon := textiles.TextIcon{Fg: g.palette.Get("black"), Bg: g.palette.Get("yellow_1")}
+	isWalkable := func(pos geometry.Point) bool {
+		return g.currentMap().IsTileWalkable(pos)
+	}
+	for _, route := range g.currentMap().GetPatrolRoutes() {
+		for i, waypoint := range route.Waypoints {
+			next := i + 1
+			if next == len(route.Waypoints) {
+				if route.PingPong {
+					break
+				}
+				next = 0
+			}
+			for _, pos := range g.currentMap().GetJPSPath(waypoint.Position, route.Waypoints[next].Position, isWalkable) {
+				if _, isSet := icons[pos]; !isSet {
+					icons[pos] = pathIcon
+				}
+			}
+		}
+		for i, waypoint := range route.Waypoints {
+			icons[waypoint.Position] = waypointIcon.WithRune(rune('0' + i%10))
+		}
+	}
+	return icons
+}
@@ -0,0 +1,118 @@
+package game
+
+import (
+	"RogueUI/special"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/textiles"
+)
+
+// darkVisionRange is how far NPCs can see into unlit areas.
+const darkVisionRange = 2
+
+// actorVision is the cached field of view of an NPC.
+// It stays valid for the rest of the turn as long as the actor doesn't move or turn.
+type actorVision struct {
+	fov      *geometry.FOV
+	turn     int
+	position geometry.Point
+	facing   geometry.CompassDirection
+}
+
+func (g *GameState) resetActorVision() {
+	g.actorVision = make(map[*Actor]*actorVision)
+}
+
+// GetVisionRange depends on the perception of the actor.
+func (a *Actor) GetVisionRange() int {
+	return 5 + a.GetCharSheet().GetStat(special.Perception)
+}
+
+// isInVisionCone checks if the position lies within 60 degrees to either side of the facing direction.
+func isInVisionCone(origin geometry.Point, facing geometry.CompassDirection, pos geometry.Point) bool {
+	offset := pos.Sub(origin)
+	if geometry.DistanceChebyshev(origin, pos) <= 1 {
+		return true
+	}
+	forward := facing.ToPoint()
+	dot := offset.X*forward.X + offset.Y*forward.Y
+	if dot <= 0 {
+		return false
+	}
+	// dot >= cos(60°) * |offset| * |forward|
+	offsetLengthSquared := offset.X*offset.X + offset.Y*offset.Y
+	forwardLengthSquared := forward.X*forward.X + forward.Y*forward.Y
+	return 4*dot*dot >= offsetLengthSquared*forwardLengthSquared
+}
+
+// actorFoV returns the field of view of an NPC. It's computed on first use each turn.
+func (g *GameState) actorFoV(actor *Actor) *geometry.FOV {
+	if actor == g.Player {
+		return g.playerFoV
+	}
+	vision, exists := g.actorVision[actor]
+	if exists && vision.turn == g.gameTime.Turns && vision.position == actor.Position() && vision.facing == actor.GetFacing() {
+		return vision.fov
+	}
+	if !exists {
+		vision = &actorVision{fov: geometry.NewFOV(geometry.NewRect(0, 0, g.currentMap().GetWidth(), g.currentMap().GetHeight()))}
+		g.actorVision[actor] = vision
+	}
+	vision.turn = g.gameTime.Turns
+	vision.position = actor.Position()
+	vision.facing = actor.GetFacing()
+
+	origin := actor.Position()
+	g.currentMap().UpdateFieldOfView(vision.fov, origin, actor.GetVisionRange())
+	vision.fov.RemoveFromVisibles(func(p geometry.Point) bool {
+		if !isInVisionCone(origin, vision.facing, p) {
+			return true
+		}
+		return g.currentMap().IsDarknessAt(g.gameTime.Time, p) && geometry.DistanceChebyshev(origin, p) > darkVisionRange
+	})
+	return vision.fov
+}
+
+// isInVisionOf checks if the observer can see the position with its own eyes.
+// Unlike canActorSee, this takes the facing, the vision range and the lighting into account.
+func (g *GameState) isInVisionOf(observer *Actor, pos geometry.Point) bool {
+	if observer == g.Player {
+		return g.canPlayerSee(pos)
+	}
+	if observer.IsSleeping() || observer.IsBlind() {
+		return false
+	}
+	return g.actorFoV(observer).Visible(pos)
+}
+
+// stealthFacingModifier is the bonus for sneaking up on someone from behind
+// and the penalty for trying it right in front of their eyes.
+func (g *GameState) stealthFacingModifier(victim *Actor) int {
+	if victim.IsSleeping() || !g.isInVisionOf(victim, g.Player.Position()) {
+		return 20
+	}
+	return -30
+}
+
+func (g *GameState) backstabChances(victim *Actor) (special.Percentage, special.Percentage) {
+	stealth := g.Player.GetCharSheet().GetSkill(special.Stealth) + g.stealthFacingModifier(victim)
+	awareness := victim.GetCharSheet().GetStat(special.Perception) * 10
+	return special.Percentage(max(0, min(100, stealth))), special.Percentage(awareness)
+}
+
+// visionConeOverlay marks everything the NPCs on the current map can see.
+func (g *GameState) visionConeOverlay() map[geometry.Point]textiles.TextIcon {
+	icons := make(map[geometry.Point]textiles.TextIcon)
+	coneIcon := textiles.TextIcon{Char: '·', Fg: g.palette.Get("red_8"), Bg: g.palette.Get("black")}
+	for _, actor := range g.currentMap().Actors() {
+		if actor == g.Player || !actor.IsAlive() || actor.IsSleeping() {
+			continue
+		}
+		for _, pos := range g.actorFoV(actor).Visibles {
+			if pos == actor.Position() || g.currentMap().IsActorAt(pos) {
+				continue
+			}
+			icons[pos] = coneIcon
+		}
+	}
+	return icons
+}
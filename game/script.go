@@ -86,6 +86,34 @@ func (g *GameState) getScriptFuncs() map[string]govaluate.ExpressionFunction {
 		"IsWounded": func(args ...interface{}) (interface{}, error) {
 			return g.Player.IsWounded(), nil
 		},
+		"PlayerNeedsHealing": func(args ...interface{}) (interface{}, error) {
+			return g.Player.NeedsHealing(), nil
+		},
+		"IsAddicted": func(args ...interface{}) (interface{}, error) {
+			return g.Player.IsAddicted(), nil
+		},
+		"HasImplants": func(args ...interface{}) (interface{}, error) {
+			return g.Player.HasImplants(), nil
+		},
+		"CanInstallImplant": func(args ...interface{}) (interface{}, error) {
+			return g.canPlayerInstallImplant(), nil
+		},
+		"HasGold": func(args ...interface{}) (interface{}, error) {
+			amount := int(args[0].(float64))
+			return g.Player.HasGold(amount), nil
+		},
+		"HasBackground": func(args ...interface{}) (interface{}, error) {
+			backgroundName := args[0].(string)
+			return g.HasBackground(backgroundName), nil
+		},
+		"HasReputation": func(args ...interface{}) (interface{}, error) {
+			team := args[0].(string)
+			return g.HasReputation(team), nil
+		},
+		"HasBadReputation": func(args ...interface{}) (interface{}, error) {
+			team := args[0].(string)
+			return g.HasBadReputation(team), nil
+		},
 		"Skill": func(args ...interface{}) (interface{}, error) {
 			skillName := args[0].(string)
 			skillValue := g.Player.GetCharSheet().GetSkill(special.SkillFromString(skillName))
@@ -94,7 +122,11 @@ func (g *GameState) getScriptFuncs() map[string]govaluate.ExpressionFunction {
 		"RollSkill": func(args ...interface{}) (interface{}, error) {
 			skillName := args[0].(string)
 			modifier := args[1].(float64)
-			result := g.Player.GetCharSheet().SkillRoll(special.SkillFromString(skillName), int(modifier))
+			skill := special.SkillFromString(skillName)
+			result := g.Player.GetCharSheet().SkillRoll(skill, int(modifier))
+			if result.Success {
+				g.learnByDoing(skill, learnGainTask, int(modifier))
+			}
 			return (bool)(result.Success), nil
 		},
 
@@ -153,11 +185,35 @@ func (g *GameState) getScriptFuncs() map[string]govaluate.ExpressionFunction {
 			g.gameFlags.ClearFlag(flagName)
 			return nil, nil
 		},
+		"FoundSecret": func(args ...interface{}) (interface{}, error) {
+			secretID := args[0].(string)
+			mapName := ""
+			if len(args) > 1 {
+				mapName = args[1].(string)
+			}
+			return g.HasFoundSecret(mapName, secretID), nil
+		},
 		"IsMap": func(args ...interface{}) (interface{}, error) {
 			mapName := args[0].(string)
 			return g.currentMap().GetName() == mapName, nil
 		},
 
+		// Alarms
+		"IsAlarmActive": func(args ...interface{}) (interface{}, error) {
+			alarmName := args[0].(string)
+			return g.IsAlarmActive(alarmName), nil
+		},
+		"TriggerAlarm": func(args ...interface{}) (interface{}, error) {
+			alarmName := args[0].(string)
+			g.triggerAlarm(alarmName, nil, g.Player.Position())
+			return nil, nil
+		},
+		"EndAlarm": func(args ...interface{}) (interface{}, error) {
+			alarmName := args[0].(string)
+			g.endAlarm(alarmName)
+			return nil, nil
+		},
+
 		// Time / Turns
 		"Turns": func(args ...interface{}) (interface{}, error) {
 			return (float64)(g.TurnsTaken()), nil
@@ -262,6 +318,38 @@ func (g *GameState) getScriptFuncs() map[string]govaluate.ExpressionFunction {
 			actor := args[0].(*Actor)
 			return !actor.IsAlive(), nil
 		},
+		"IsActorAtSpawn": func(args ...interface{}) (interface{}, error) {
+			actor := args[0].(*Actor)
+			return actor.Position() == actor.SpawnPosition, nil
+		},
+		"CanActorSeeActor": func(args ...interface{}) (interface{}, error) {
+			actor := args[0].(*Actor)
+			target := args[1].(*Actor)
+			return g.canActorSee(actor, target.Position()), nil
+		},
+		"WantsToAttack": func(args ...interface{}) (interface{}, error) {
+			actor := args[0].(*Actor)
+			target := args[1].(*Actor)
+			return actor.WantsToAttack(target), nil
+		},
+		"ActorMemory": func(args ...interface{}) (interface{}, error) {
+			actor := args[0].(*Actor)
+			key := args[1].(string)
+			return actor.GetBlackboard(key), nil
+		},
+		"SetActorBehaviour": func(args ...interface{}) (interface{}, error) {
+			actor := args[0].(*Actor)
+			name := args[1].(string)
+			actor.SetBehaviour(name)
+			return nil, nil
+		},
+		"IsSurrendered": func(args ...interface{}) (interface{}, error) {
+			actor := g.conversationPartner
+			if len(args) > 0 {
+				actor = args[0].(*Actor)
+			}
+			return actor != nil && actor.IsSurrendered(), nil
+		},
 		"IsActorInCombat": func(args ...interface{}) (interface{}, error) {
 			actor := args[0].(*Actor)
 			if actor.IsInCombat() {
@@ -419,7 +507,12 @@ func tryKill(g *GameState, a *Actor, target *Actor) int {
 		return mainHandItem.GetCurrentAttackMode().TUCost
 	}
 
-	return a.timeNeededForActions()
+	if geometry.DistanceChebyshev(a.Position(), target.Position()) <= 1 {
+		g.ui.AddAnimations(g.actorMeleeAttack(a, target, special.Body))
+		return a.GetMeleeTUCost()
+	}
+
+	return moveTowards(g, a, target.Position())
 }
 func moveIntoShootingRange(g *GameState, a *Actor, target *Actor) int {
 	weaponRange := a.GetWeaponRange()
@@ -429,6 +522,9 @@ func moveIntoShootingRange(g *GameState, a *Actor, target *Actor) int {
 }
 
 func moveTowards(g *GameState, a *Actor, targetPos geometry.Point) int {
+	if a.IsStationary() {
+		return a.timeEnergy
+	}
 	nextMovePos := a.getMoveTowards(g, targetPos)
 	if nextMovePos == a.Position() {
 		return a.timeEnergy
@@ -0,0 +1,142 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"slices"
+)
+
+const (
+	passiveSearchRadius   = 2
+	passiveSearchModifier = -30
+	activeSearchRadius    = 3
+	activeSearchModifier  = 10
+	activeSearchTimeCost  = 50
+)
+
+// PlayerSearch takes some time to look for hidden doors, caches and switches around the player.
+func (g *GameState) PlayerSearch() {
+	g.msg(foundation.Msg("You search your surroundings"))
+
+	searchArea := g.currentMap().GetDijkstraMap(g.Player.Position(), activeSearchRadius, func(p geometry.Point) bool {
+		return g.currentMap().IsTileWalkable(p) || g.currentMap().HasWalkableNeighbor(p)
+	})
+	searchAnim := g.ui.GetAnimRadialReveal(g.Player.Position(), searchArea, nil)
+	g.ui.AddAnimations(OneAnimation(searchAnim))
+
+	if !g.checkTilesForHiddenObjects(g.tilesAroundPlayer(activeSearchRadius), activeSearchModifier) {
+		g.msg(foundation.Msg("You find nothing of interest"))
+	}
+
+	g.endPlayerTurn(activeSearchTimeCost)
+}
+
+// passiveSearch gives the player a chance to notice secrets while walking by.
+func (g *GameState) passiveSearch() {
+	g.checkTilesForHiddenObjects(g.tilesAroundPlayer(passiveSearchRadius), passiveSearchModifier)
+}
+
+func (g *GameState) tilesAroundPlayer(radius int) []geometry.Point {
+	var tiles []geometry.Point
+	playerPos := g.Player.Position()
+	for y := playerPos.Y - radius; y <= playerPos.Y+radius; y++ {
+		for x := playerPos.X - radius; x <= playerPos.X+radius; x++ {
+			pos := geometry.Point{X: x, Y: y}
+			if g.currentMap().Contains(pos) && g.canPlayerSee(pos) {
+				tiles = append(tiles, pos)
+			}
+		}
+	}
+	return tiles
+}
+
+// checkTilesForHiddenObjects rolls Perception against the discovery difficulty
+// of every hidden object on the given tiles. Returns true if something was found.
+func (g *GameState) checkTilesForHiddenObjects(tiles []geometry.Point, modifier int) bool {
+	var foundSomething bool
+	for _, tile := range tiles {
+		object, exists := g.currentMap().TryGetObjectAt(tile)
+		if !exists || !object.IsHidden() {
+			continue
+		}
+		rollModifier := modifier + object.GetDiscoveryDifficulty().GetRollModifier()
+		perceptionResult := g.Player.GetCharSheet().StatRoll(special.Perception, rollModifier)
+		// knowing how traps are built helps to spot them
+		if !perceptionResult.Success && object.IsTrap() {
+			perceptionResult = g.Player.GetCharSheet().SkillRoll(special.Mechanics, rollModifier)
+		}
+		if perceptionResult.Success {
+			g.revealSecret(object)
+			foundSomething = true
+		}
+	}
+	if foundSomething {
+		g.updateDijkstraMap()
+	}
+	return foundSomething
+}
+
+func (g *GameState) revealSecret(object Object) {
+	object.SetHidden(false)
+
+	mapName := g.currentMap().GetName()
+	secretID := object.SecretID()
+	if !g.HasFoundSecret(mapName, secretID) {
+		g.foundSecrets[mapName] = append(g.foundSecrets[mapName], secretID)
+	}
+
+	if object.IsTrap() {
+		g.msg(foundation.HiLite("You spotted %s!", object.Name()))
+	} else {
+		g.msg(foundation.HiLite("You discovered %s!", object.Name()))
+	}
+	revealAnim := g.ui.GetAnimBackgroundColor(object.Position(), "yellow_2", 8, nil)
+	g.ui.AddAnimations(OneAnimation(revealAnim))
+}
+
+// HasFoundSecret checks the secrets found on the given map.
+// An empty map name checks all maps.
+func (g *GameState) HasFoundSecret(mapName, secretID string) bool {
+	if mapName != "" {
+		return slices.Contains(g.foundSecrets[mapName], secretID)
+	}
+	for _, secrets := range g.foundSecrets {
+		if slices.Contains(secrets, secretID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GameState) foundSecretsToRecords() []recfile.Record {
+	var recs []recfile.Record
+	for mapName, secrets := range g.foundSecrets {
+		record := recfile.Record{
+			recfile.Field{Name: "map", Value: mapName},
+		}
+		for _, secret := range secrets {
+			record = append(record, recfile.Field{Name: "secret", Value: secret})
+		}
+		recs = append(recs, record)
+	}
+	return recs
+}
+
+func (g *GameState) foundSecretsFromRecords(records []recfile.Record) map[string][]string {
+	result := make(map[string][]string)
+	for _, record := range records {
+		var mapName string
+		var secrets []string
+		for _, field := range record {
+			if field.Name == "map" {
+				mapName = field.Value
+			} else if field.Name == "secret" {
+				secrets = append(secrets, field.Value)
+			}
+		}
+		result[mapName] = secrets
+	}
+	return result
+}
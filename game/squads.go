@@ -0,0 +1,298 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"github.com/memmaker/go/geometry"
+)
+
+type SquadRole uint8
+
+const (
+	// SquadRoleAssault closes in and attacks
+	SquadRoleAssault SquadRole = iota
+	// SquadRoleSuppress holds position and keeps the target busy
+	SquadRoleSuppress
+	// SquadRoleFlank moves around the target to attack it from the side
+	SquadRoleFlank
+	// SquadRoleFallBack is given to wounded members, they keep their distance
+	SquadRoleFallBack
+)
+
+// squadKnowledge is shared by all members of a squad on the current map.
+type squadKnowledge struct {
+	Target            *Actor
+	LastKnownPosition geometry.Point
+	HasLastKnown      bool
+}
+
+// GetSquad returns the group ID from the map or the team name of the actor.
+func (a *Actor) GetSquad() string {
+	if a.squadName != "" {
+		return a.squadName
+	}
+	return a.teamName
+}
+
+func (a *Actor) SetSquad(name string) {
+	a.squadName = name
+}
+
+func (g *GameState) resetSquadKnowledge() {
+	g.squadKnowledge = make(map[string]*squadKnowledge)
+}
+
+func (g *GameState) getSquadKnowledge(squadName string) *squadKnowledge {
+	knowledge, exists := g.squadKnowledge[squadName]
+	if !exists {
+		knowledge = &squadKnowledge{}
+		g.squadKnowledge[squadName] = knowledge
+	}
+	return knowledge
+}
+
+func (g *GameState) squadMembers(squadName string) []*Actor {
+	var members []*Actor
+	for _, actor := range g.currentMap().Actors() {
+		if actor != g.Player && actor.IsAlive() && !actor.IsSurrendered() && !actor.IsPanicking() && actor.GetSquad() == squadName {
+			members = append(members, actor)
+		}
+	}
+	return members
+}
+
+// squadRole hands out the roles in the order the members appear on the map.
+// The first member with a ranged weapon suppresses, every second of the others flanks.
+func (g *GameState) squadRole(actor *Actor, members []*Actor) SquadRole {
+	if actor.GetHitPoints() < actor.GetHitPointsMax()/2 && len(members) > 1 {
+		return SquadRoleFallBack
+	}
+	var suppressor *Actor
+	for _, member := range members {
+		if member.GetEquipment().HasRangedWeaponEquipped() || member.GetInventory().GetBestRangedWeapon() != nil {
+			suppressor = member
+			break
+		}
+	}
+	if actor == suppressor {
+		return SquadRoleSuppress
+	}
+	index := 0
+	for _, member := range members {
+		if member == suppressor {
+			continue
+		}
+		if member == actor {
+			break
+		}
+		index++
+	}
+	if suppressor != nil && index%2 == 0 {
+		return SquadRoleFlank
+	}
+	return SquadRoleAssault
+}
+
+func (g *GameState) squadTargetOf(actor *Actor, knowledge *squadKnowledge) *Actor {
+	if knowledge.Target != nil && knowledge.Target.IsAlive() && g.isAwareOfSquadTarget(actor, knowledge.Target) {
+		return knowledge.Target
+	}
+	return g.selectTarget(actor)
+}
+
+// isAwareOfSquadTarget is true if the member is fighting the target already, can see it or was alerted.
+// Members that are unaware keep doing what they were doing until they notice the fight.
+func (g *GameState) isAwareOfSquadTarget(actor *Actor, target *Actor) bool {
+	if actor.WantsToAttack(target) || actor.IsAlerted() {
+		return true
+	}
+	return g.isInVisionOf(actor, target.Position()) && CanPerceive(actor, target)
+}
+
+// trySquadAction coordinates actors that fight as part of a squad.
+// Returns false if the actor is on its own or has no one to fight, so the regular AI can take over.
+func (g *GameState) trySquadAction(actor *Actor) (int, bool) {
+	squadName := actor.GetSquad()
+	if squadName == "" || squadName == g.Player.GetTeam() {
+		return 0, false
+	}
+	if actor.HasActiveGoal() {
+		// scripted goals come first
+		return 0, false
+	}
+	members := g.squadMembers(squadName)
+	if len(members) < 2 {
+		return 0, false
+	}
+	knowledge := g.getSquadKnowledge(squadName)
+	target := g.squadTargetOf(actor, knowledge)
+	if target == nil || !target.IsAlive() {
+		if knowledge.Target != nil && !knowledge.Target.IsAlive() {
+			*knowledge = squadKnowledge{}
+		}
+		return 0, false
+	}
+
+	// squad mates join the fight of the others
+	if !actor.WantsToAttack(target) {
+		actor.SetHostileTowards(target)
+	}
+
+	knowledge.Target = target
+	if g.isInVisionOf(actor, target.Position()) && CanPerceive(actor, target) {
+		knowledge.LastKnownPosition = target.Position()
+		knowledge.HasLastKnown = true
+	} else {
+		return g.squadSearch(actor, knowledge), true
+	}
+
+	if grenade := g.findGrenadeToThrow(actor, target, members); grenade != nil {
+		attackMode := grenade.GetCurrentAttackMode()
+		g.actorThrowItem(actor, grenade, actor.Position(), target.Position())
+		return attackMode.TUCost, true
+	}
+
+	switch g.squadRole(actor, members) {
+	case SquadRoleFallBack:
+		return g.squadFallBack(actor, target), true
+	case SquadRoleSuppress:
+		if g.IsInShootingRange(actor, target) {
+			return g.squadAttack(actor, target), true
+		}
+		return moveIntoShootingRange(g, actor, target), true
+	case SquadRoleFlank:
+		return g.squadFlank(actor, target, members), true
+	}
+	return g.squadAttack(actor, target), true
+}
+
+// squadSearch moves towards the position the target was last seen at.
+func (g *GameState) squadSearch(actor *Actor, knowledge *squadKnowledge) int {
+	if !knowledge.HasLastKnown {
+		return actor.timeEnergy
+	}
+	if geometry.DistanceChebyshev(actor.Position(), knowledge.LastKnownPosition) <= 1 {
+		// nobody here, the trail is cold
+		knowledge.HasLastKnown = false
+		return actor.timeEnergy
+	}
+	tuSpent := moveTowards(g, actor, knowledge.LastKnownPosition)
+	if actor.cannotFindPath() {
+		knowledge.HasLastKnown = false
+	}
+	return tuSpent
+}
+
+func (g *GameState) squadAttack(actor *Actor, target *Actor) int {
+	if tuSpent, used := g.tryUseAbility(actor, target); used {
+		return tuSpent
+	}
+	if geometry.DistanceChebyshev(actor.Position(), target.Position()) <= 1 && !actor.GetEquipment().HasRangedWeaponInMainHand() {
+		g.ui.AddAnimations(g.actorMeleeAttack(actor, target, special.Body))
+		return actor.GetMeleeTUCost()
+	}
+	return tryKill(g, actor, target)
+}
+
+// squadFallBack keeps wounded members out of melee, they only shoot from a distance.
+func (g *GameState) squadFallBack(actor *Actor, target *Actor) int {
+	if geometry.DistanceChebyshev(actor.Position(), target.Position()) <= 3 {
+		if retreatPos := g.retreatPosition(actor, target); retreatPos != actor.Position() {
+			g.ui.AddAnimations(g.actorMoveAnimated(actor, retreatPos))
+			return actor.timeNeededForMovement()
+		}
+	}
+	if g.IsInShootingRange(actor, target) && actor.GetEquipment().HasRangedWeaponEquipped() {
+		return tryKill(g, actor, target)
+	}
+	return actor.timeEnergy
+}
+
+func (g *GameState) retreatPosition(actor *Actor, target *Actor) geometry.Point {
+	if target == g.Player {
+		return g.currentMap().GetMoveOnPlayerDijkstraMap(actor.Position(), false, g.playerDijkstraMap)
+	}
+	bestPos := actor.Position()
+	bestDistance := geometry.DistanceSquared(bestPos, target.Position())
+	for _, neighbor := range g.currentMap().GetFilteredNeighbors(actor.Position(), g.currentMap().IsCurrentlyPassable) {
+		if distance := geometry.DistanceSquared(neighbor, target.Position()); distance > bestDistance {
+			bestPos = neighbor
+			bestDistance = distance
+		}
+	}
+	return bestPos
+}
+
+// squadFlank walks to a position to the side of the target, as seen from the member holding the line.
+// Once there, or if the way is blocked, the flanker attacks.
+func (g *GameState) squadFlank(actor *Actor, target *Actor, members []*Actor) int {
+	var anchor *Actor
+	for _, member := range members {
+		if member != actor && g.squadRole(member, members) == SquadRoleSuppress {
+			anchor = member
+			break
+		}
+	}
+	if anchor == nil {
+		return g.squadAttack(actor, target)
+	}
+	front := anchor.Position().Sub(target.Position())
+	side := geometry.Point{X: -geometry.Sign(front.Y), Y: geometry.Sign(front.X)}
+	ownOffset := actor.Position().Sub(target.Position())
+	if ownOffset.X*side.X+ownOffset.Y*side.Y < 0 {
+		side = geometry.Point{X: -side.X, Y: -side.Y}
+	}
+	flankDistance := max(2, min(actor.GetWeaponRange()/2, 5))
+	flankPos := target.Position().Add(geometry.Point{X: side.X * flankDistance, Y: side.Y * flankDistance})
+
+	isInPosition := geometry.DistanceChebyshev(actor.Position(), flankPos) <= 1
+	if isInPosition || !g.currentMap().Contains(flankPos) || !g.currentMap().IsCurrentlyPassable(flankPos) {
+		return g.squadAttack(actor, target)
+	}
+	tuSpent := moveTowards(g, actor, flankPos)
+	if actor.cannotFindPath() {
+		return g.squadAttack(actor, target)
+	}
+	return tuSpent
+}
+
+// findGrenadeToThrow returns an explosive if at least two enemies of the squad stand close together
+// and no squad member would be caught in the blast.
+func (g *GameState) findGrenadeToThrow(actor *Actor, target *Actor, members []*Actor) *Weapon {
+	var grenade *Weapon
+	for _, item := range actor.GetInventory().Items() {
+		if weapon, isWeapon := item.(*Weapon); isWeapon && weapon.GetCurrentAttackMode().IsThrow() && weapon.ZapEffect() != "" && weapon.GetDamageType() != special.DamageTypeNormal {
+			grenade = weapon
+			break
+		}
+	}
+	if grenade == nil {
+		return nil
+	}
+	distance := geometry.DistanceChebyshev(actor.Position(), target.Position())
+	if distance < 3 || distance > grenade.GetCurrentAttackMode().MaxRange {
+		return nil
+	}
+	blastRadius := blastRadiusOf(grenade)
+	for _, member := range members {
+		if geometry.DistanceChebyshev(member.Position(), target.Position()) <= blastRadius {
+			return nil
+		}
+	}
+	clustered := g.currentMap().GetFilteredActorsInRadius(target.Position(), blastRadius, func(other *Actor) bool {
+		return other.IsAlive() && (other == target || actor.WantsToAttack(other) || other.IsHostileTowards(actor))
+	})
+	if len(clustered) < 2 {
+		return nil
+	}
+	if g.canPlayerSee(actor.Position()) {
+		g.msg(foundation.HiLite("%s pulls the pin of %s", actor.Name(), grenade.Name()))
+	}
+	return grenade
+}
+
+// blastRadiusOf is the radius of the explosion or cloud caused by the thrown item.
+func blastRadiusOf(grenade *Weapon) int {
+	params := grenade.GetEffectParameters()
+	return params.GetIntOrDefault("radius", 3) + params.GetIntOrDefault("bonus_radius", 0)
+}
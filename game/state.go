@@ -103,6 +103,8 @@ func (g *GameState) actorKilled(causeOfDeath SourcedDamage, victim *Actor) {
 		g.gameFlags.Increment("PlayerKillCount")
 	}
 
+	g.onAllyDeath(victim)
+
 	//g.dropInventory(victim)
 	g.currentMap().SetActorToDowned(victim)
 
@@ -207,6 +209,11 @@ func (g *GameState) checkPlayerCanAct() {
 	// then check the end condition for this status effect
 	// if it's not reached, we want the UI to show a message about the situation
 	// the player has to confirm it and then we can end the turn
+	if g.Player.IsKnockedDown() {
+		g.msg(foundation.Msg("You are down and cannot act"))
+		g.endPlayerTurn(g.Player.timeNeededForActions())
+		return
+	}
 	if !g.Player.HasFlag(foundation.FlagStun) && !g.Player.HasFlag(foundation.FlagHeld) {
 		return
 	}
@@ -269,6 +276,9 @@ func (g *GameState) triggerTileEffectsAfterMovement(actor *Actor, oldPos, newPos
 
 func (g *GameState) buyItemFromVendor(item foundation.Item, price int) {
 	player := g.Player
+	if item.IsDrug() {
+		price = player.GetCharSheet().ApplyTraitRule(special.RuleDrugPrice, price)
+	}
 	if !player.HasGold(price) {
 		g.msg(foundation.Msg("You cannot afford that"))
 		return
@@ -287,28 +297,6 @@ func (g *GameState) newLevelReached(level int) {
 	g.msg(foundation.HiLite("You've been awarded 10 character points for reaching level %s", fmt.Sprint(level)))
 }
 
-func (g *GameState) checkTilesForHiddenObjects(tiles []geometry.Point) {
-	var noticedSomething bool
-	for _, tile := range tiles {
-		if g.currentMap().IsObjectAt(tile) {
-			object := g.currentMap().ObjectAt(tile)
-			if object.IsHidden() {
-				perceptionResult := g.Player.GetCharSheet().StatRoll(special.Perception, 0)
-				if perceptionResult.Success {
-					noticedSomething = true
-				}
-				if perceptionResult.Crit {
-					object.SetHidden(false)
-				}
-			}
-		}
-	}
-
-	if noticedSomething {
-		g.msg(foundation.Msg("you feel like something is wrong with this room"))
-	}
-}
-
 func (g *GameState) dropInventory(victim *Actor) {
 	goldAmount := victim.GetGold()
 	if goldAmount > 0 {
@@ -171,6 +171,8 @@ type GameState struct {
 	timeTracker          TimeTracker
 	logBuffer            []foundation.HiLiteString
 	terminalGuesses      map[string][]string
+	foundSecrets         map[string][]string
+	activeAlarms         map[string]*activeAlarm
 	journal              *Journal
 	showEverything       bool
 	flagsChangedThisTurn bool
@@ -183,7 +185,8 @@ type GameState struct {
 	playerLastAimedAt special.BodyPart
 
 	// Map State
-	mapLoader MapLoader
+	mapLoader        MapLoader
+	triggerOccupants triggerOccupants
 
 	// Scripts
 	scriptRunner *ScriptRunner
@@ -192,6 +195,9 @@ type GameState struct {
 	// Maps (Needs to be saved)
 	activeMaps     map[string]*gridmap.GridMap[*Actor, foundation.Item, Object]
 	currentMapName string
+	mapLeftAt      map[string]time.Time
+
+	isSimulatingMap bool
 
 	visionRange int
 
@@ -210,8 +216,20 @@ type GameState struct {
 	globalItemTemplates map[string]recfile.Record
 	mapItemTemplates    map[string]recfile.Record
 
+	// Character Creation
+	traitCatalogue      []special.Trait
+	backgroundCatalogue []Background
+
+	// Cyberware
+	implantCatalogue map[string]Implant
+
 	// Temporary State
-	chatterCache map[*Actor]map[foundation.ChatterType][]EntriesWithCondition
+	chatterCache   map[*Actor]map[foundation.ChatterType][]EntriesWithCondition
+	behaviourTrees map[string]*BehaviourTree
+
+	conversationPartner *Actor
+	squadKnowledge      map[string]*squadKnowledge
+	actorVision         map[*Actor]*actorVision
 }
 
 func (g *GameState) PlayerToggleRun() {
@@ -236,7 +254,7 @@ func (g *GameState) IsPlayerAndMapInitialized() bool {
 
 func (g *GameState) IsActorHostileTowardsPlayer(enemy foundation.ActorForUI) bool {
 	actor := enemy.(*Actor)
-	return actor.IsHostileTowards(g.Player)
+	return actor.IsEnemyOf(g.Player)
 }
 
 func (g *GameState) IsActorAlliedWithPlayer(ally foundation.ActorForUI) bool {
@@ -348,6 +366,9 @@ func NewGameState(ui foundation.GameUI, config *foundation.Configuration) *GameS
 		visionRange:         80,
 		palette:             palette,
 		globalItemTemplates: loadItemTemplates(config.DataRootDir),
+		traitCatalogue:      loadTraits(config.DataRootDir),
+		backgroundCatalogue: loadBackgrounds(config.DataRootDir),
+		implantCatalogue:    loadImplants(config.DataRootDir),
 		activeMaps:          make(map[string]*gridmap.GridMap[*Actor, foundation.Item, Object]),
 		chatterCache:        make(map[*Actor]map[foundation.ChatterType][]EntriesWithCondition),
 	}
@@ -363,7 +384,10 @@ func (g *GameState) GetPlayerNameAndIcon() (string, textiles.TextIcon) {
 	}
 }
 func (g *GameState) NewActor(rec recfile.Record) (*Actor, geometry.Point) {
-	newActor := NewActorFromRecord(rec, g.palette, g.NewItemFromString)
+	newActor, err := NewActorFromRecord(rec, g.palette, g.NewItemFromString)
+	if err != nil {
+		panic(err)
+	}
 	if newActor != nil {
 		spawnPos := newActor.Position()
 		newActor.SpawnPosition = spawnPos
@@ -416,6 +440,13 @@ func (g *GameState) init() {
 	g.gameFlags = fxtools.NewStringFlags()
 
 	g.terminalGuesses = make(map[string][]string)
+	g.foundSecrets = make(map[string][]string)
+	g.activeAlarms = make(map[string]*activeAlarm)
+	g.mapLeftAt = make(map[string]time.Time)
+	g.triggerOccupants = make(triggerOccupants)
+	g.behaviourTrees = make(map[string]*BehaviourTree)
+	g.resetSquadKnowledge()
+	g.resetActorVision()
 
 	g.journal = NewJournal(fxtools.MustOpen(path.Join(g.config.DataRootDir, "definitions", "journal.rec")), g.getScriptFuncs())
 	g.hookupJournalAndFlags()
@@ -435,7 +466,8 @@ func (g *GameState) hookupJournalAndFlags() {
 	})
 }
 
-func (g *GameState) initPlayerAndMap() {
+// initPlayerAndMap creates the player and loads the start map. The background is optional and replaces the default start.
+func (g *GameState) initPlayerAndMap(background *Background) {
 	playerSheet := special.NewCharSheet()
 	playerSheet.AddSkillPoints(0)
 
@@ -443,6 +475,7 @@ func (g *GameState) initPlayerAndMap() {
 	playerName, playerIcon := g.GetPlayerNameAndIcon()
 	g.Player = NewPlayer(playerName, playerIcon, playerSheet)
 	var spawnMap, spawnLocation string
+	useStartGear := background == nil || len(background.Items) == 0
 	playerStartInfo := path.Join(g.config.DataRootDir, "definitions", "player_start.rec")
 	if fxtools.FileExists(playerStartInfo) {
 		startGear := recfile.Read(fxtools.MustOpen(playerStartInfo))[0]
@@ -451,7 +484,7 @@ func (g *GameState) initPlayerAndMap() {
 				spawnMap = field.Value
 			} else if field.Name == "mapLocation" {
 				spawnLocation = field.Value
-			} else if field.Name == "item" {
+			} else if field.Name == "item" && useStartGear {
 				itemName := field.Value
 				newItemFromString := g.NewItemFromString(itemName)
 				g.giveAndTryEquipItem(g.Player, newItemFromString)
@@ -459,6 +492,13 @@ func (g *GameState) initPlayerAndMap() {
 		}
 	}
 
+	if background != nil {
+		g.applyBackground(*background)
+		if background.MapName != "" && background.MapLocation != "" {
+			spawnMap, spawnLocation = background.MapName, background.MapLocation
+		}
+	}
+
 	loadedMapResult := g.mapLoader.LoadMap(spawnMap)
 
 	loadedMap := loadedMapResult.Map
@@ -508,23 +548,44 @@ func (g *GameState) attachHooksToPlayer() {
 	g.Player.GetInventory().SetOnChangeHandler(g.ui.UpdateInventory)
 
 	g.Player.GetInventory().SetOnBeforeRemove(equipment.UnEquip)
+	g.Player.GetInventory().SetIsEquippedTest(equipment.IsEquipped)
 
 	g.Player.GetCharSheet().SetSkillModifierHandler(func(skill special.Skill) []special.Modifier {
-		modsFromItems := g.Player.GetInventory().GetSkillModifiersFromItems(skill)
-		modsFromActiveEffects := g.Player.GetTemporarySkillModifiers(skill)
-		return append(modsFromItems, modsFromActiveEffects...)
+		var result []special.Modifier
+		for _, modifiersFrom := range []func(special.Skill) []special.Modifier{
+			g.Player.GetInventory().GetSkillModifiersFromItems,
+			g.Player.GetTemporarySkillModifiers,
+			g.Player.GetImplantSkillModifiers,
+		} {
+			result = append(result, modifiersFrom(skill)...)
+		}
+		return result
 	})
 
 	g.Player.GetCharSheet().SetStatModifierHandler(func(stat special.Stat) []special.Modifier {
-		modsFromItems := g.Player.GetInventory().GetStatModifiersFromItems(stat)
-		modsFromActiveEffects := g.Player.GetTemporaryStatModifiers(stat)
-		return append(modsFromItems, modsFromActiveEffects...)
+		var result []special.Modifier
+		for _, modifiersFrom := range []func(special.Stat) []special.Modifier{
+			g.Player.GetInventory().GetStatModifiersFromItems,
+			g.Player.GetTemporaryStatModifiers,
+			g.Player.GetInjuryStatModifiers,
+			g.Player.GetSurvivalStatModifiers,
+			g.Player.GetImplantStatModifiers,
+		} {
+			result = append(result, modifiersFrom(stat)...)
+		}
+		return result
 	})
 
 	g.Player.GetCharSheet().SetDerivedStatModifierHandler(func(stat special.DerivedStat) []special.Modifier {
-		modsFromItems := g.Player.GetInventory().GetDerivedStatModifiersFromItems(stat)
-		modsFromActiveEffects := g.Player.GetTemporaryDerivedStatModifiers(stat)
-		return append(modsFromItems, modsFromActiveEffects...)
+		var result []special.Modifier
+		for _, modifiersFrom := range []func(special.DerivedStat) []special.Modifier{
+			g.Player.GetInventory().GetDerivedStatModifiersFromItems,
+			g.Player.GetTemporaryDerivedStatModifiers,
+			g.Player.GetImplantDerivedStatModifiers,
+		} {
+			result = append(result, modifiersFrom(stat)...)
+		}
+		return result
 	})
 
 	equipment.SetOnChangeHandler(g.updateUIStatus)
@@ -546,25 +607,35 @@ func (g *GameState) UIReady() {
 	g.moveIntoDungeon()
 	// ADD Banner
 	//g.ui.ShowTextFileFullscreen(path.Join("data","banner.txt"), g.moveIntoDungeon)
-	g.scriptRunner.CheckAndRunFrames(g.currentMap().GetName())
 }
 
 // moveIntoDungeon requires the UI to be available. It will request a dungeon crawl UI
 // and then moves the player into the loaded map.
+// If there are backgrounds to choose from, the player is created after the choice was made.
 func (g *GameState) moveIntoDungeon() {
+	if len(g.backgroundCatalogue) == 0 {
+		g.enterDungeon(nil)
+		return
+	}
+	g.openBackgroundMenu()
+}
 
+func (g *GameState) enterDungeon(background *Background) {
 	// Since the player has equipment, we need the item
-	g.initPlayerAndMap()
+	g.initPlayerAndMap(background)
 
 	g.afterPlayerMoved(geometry.Point{}, true)
 
 	g.updateUIStatus()
+
+	g.ui.UpdateInventory()
+
+	g.scriptRunner.CheckAndRunFrames(g.currentMap().GetName())
 }
 
 func (g *GameState) Reset() {
 	g.init()
 	g.moveIntoDungeon()
-	g.ui.UpdateInventory()
 }
 
 func (g *GameState) QueueActionAfterAnimation(action func()) {
@@ -591,6 +662,18 @@ func (g *GameState) endPlayerTurn(playerTimeTakenForTurn int) {
 
 	g.enemyMovement(playerTimeTakenForTurn)
 
+	g.updateEnvironmentalFields()
+
+	g.updateBleeding()
+
+	if g.config.SurvivalMode {
+		g.updateSurvivalNeeds()
+	}
+
+	g.updateTriggers()
+
+	g.updateAlarms()
+
 	if didCancel {
 		g.ui.SkipAnimations()
 	} else {
@@ -710,6 +793,8 @@ func (g *GameState) PlayerStealOrPlantItem(victim *Actor, item foundation.Item,
 	}
 	if victim.IsSleeping() {
 		itemStealModifier += 75
+	} else {
+		itemStealModifier += g.stealthFacingModifier(victim)
 	}
 
 	var transferFunc func(foundation.Item)
@@ -820,6 +905,7 @@ func (g *GameState) advanceTimeAndTurn(duration time.Duration) {
 }
 func (g *GameState) advanceTime(duration time.Duration) {
 	g.gameTime = g.gameTime.AddDuration(duration)
+	g.simulateMap(g.currentMap(), duration)
 	g.scriptRunner.CheckAndRunFrames(g.currentMap().GetName())
 	g.updatePlayerFoVAndApplyExploration()
 }
@@ -828,6 +914,7 @@ func (g *GameState) awardXP(xp int, text string) {
 	didLevelUpNow := g.Player.GetCharSheet().AddXP(xp)
 	g.msg(foundation.HiLite("You received %s ("+text+")", strconv.Itoa(xp)+" XP"))
 	if didLevelUpNow {
+		g.withholdLevelUpSkillPoints()
 		g.ui.PlayCue("ui/LEVELUP")
 		g.msg(foundation.HiLite(">>> You have gone up a level <<<"))
 	}
@@ -951,19 +1038,3 @@ func (g *GameState) removeItemFromGame(item foundation.Item) {
 		}
 	}
 }
-
-func (g *GameState) actorConsumeDrug(actor *Actor, item *GenericItem) {
-	actor.AddTemporaryStatChange(&TemporaryStatChange{
-		StatChange: item.statChanges,
-		Name:       item.Name(),
-		TurnsLeft:  item.charges,
-	})
-
-	if g.Player == actor {
-		g.msg(foundation.HiLite("You consume %s", item.Name()))
-	} else {
-		g.msg(foundation.HiLite("%s consumes %s", actor.Name(), item.Name()))
-	}
-
-	g.removeItemFromInventory(actor, item)
-}
@@ -69,6 +69,7 @@ func (g *GameState) OpenContextMenuFor(mapPos geometry.Point) bool {
 		object := g.currentMap().ObjectAt(mapPos)
 		menuItems = object.AppendContextActions(menuItems, g)
 	}
+	menuItems = g.appendContextActionsForTile(menuItems, mapPos)
 	if len(menuItems) == 0 {
 		return false
 	}
@@ -115,7 +116,7 @@ func (g *GameState) OpenHitLocationMenu() {
 func (g *GameState) PlayerRest(duration time.Duration) {
 	g.ui.FadeToBlack()
 	g.advanceTime(duration)
-	if g.Player.GetInventory().HasWatch() {
+	if g.Player.GetEquipment().HasWatch() {
 		g.msg(foundation.Msg(fmt.Sprintf("Time is now %s", g.gameTime.Time.Format("15:04"))))
 	}
 	g.ui.FadeFromBlack()
@@ -144,31 +145,41 @@ func (g *GameState) LoadGame(fromDirectory string) {
 		g.msg(foundation.Msg("Game loaded."))
 	}
 }
+
+// OpenRestMenu lets the player wait anywhere. Sleeping is only possible next to a bed.
 func (g *GameState) OpenRestMenu() {
-	g.ui.OpenMenu([]foundation.MenuItem{
+	var menuItems []foundation.MenuItem
+	if g.isPlayerNextToBed() {
+		menuItems = append(menuItems, foundation.MenuItem{
+			Name:       "Sleep",
+			Action:     g.OpenSleepMenu,
+			CloseMenus: false,
+		})
+	}
+	g.ui.OpenMenu(append(menuItems, []foundation.MenuItem{
 		{
-			Name: "Rest for ten minutes",
+			Name: "Wait for ten minutes",
 			Action: func() {
 				g.PlayerRest(10 * time.Minute)
 			},
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest for thirty minutes",
+			Name: "Wait for thirty minutes",
 			Action: func() {
 				g.PlayerRest(30 * time.Minute)
 			},
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest for an hour",
+			Name: "Wait for an hour",
 			Action: func() {
 				g.PlayerRest(time.Hour)
 			},
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest for two hours",
+			Name: "Wait for two hours",
 			Action: func() {
 				g.PlayerRest(2 * time.Hour)
 			},
@@ -176,35 +187,35 @@ func (g *GameState) OpenRestMenu() {
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest for three hours",
+			Name: "Wait for three hours",
 			Action: func() {
 				g.PlayerRest(3 * time.Hour)
 			},
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest for four hours",
+			Name: "Wait for four hours",
 			Action: func() {
 				g.PlayerRest(4 * time.Hour)
 			},
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest for five hours",
+			Name: "Wait for five hours",
 			Action: func() {
 				g.PlayerRest(5 * time.Hour)
 			},
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest for six hours",
+			Name: "Wait for six hours",
 			Action: func() {
 				g.PlayerRest(6 * time.Hour)
 			},
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest until morning (0600)",
+			Name: "Wait until morning (0600)",
 			Action: func() {
 				now := g.gameTime.Time
 				morning := time.Date(now.Year(), now.Month(), now.Day(), 6, 0, 0, 0, now.Location())
@@ -216,7 +227,7 @@ func (g *GameState) OpenRestMenu() {
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest until noon (1200)",
+			Name: "Wait until noon (1200)",
 			Action: func() {
 				now := g.gameTime.Time
 				noon := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
@@ -228,7 +239,7 @@ func (g *GameState) OpenRestMenu() {
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest until evening (1800)",
+			Name: "Wait until evening (1800)",
 			Action: func() {
 				now := g.gameTime.Time
 				evening := time.Date(now.Year(), now.Month(), now.Day(), 18, 0, 0, 0, now.Location())
@@ -240,7 +251,7 @@ func (g *GameState) OpenRestMenu() {
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest until midnight (0000)",
+			Name: "Wait until midnight (0000)",
 			Action: func() {
 				now := g.gameTime.Time
 				midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
@@ -252,13 +263,13 @@ func (g *GameState) OpenRestMenu() {
 			CloseMenus: true,
 		},
 		{
-			Name: "Rest until healed",
+			Name: "Wait until healed",
 			Action: func() {
 				g.PlayerRest(time.Hour * 48) // TODO: change this?
 			},
 			CloseMenus: true,
 		},
-	})
+	}...))
 }
 func (g *GameState) OpenWizardMenu() {
 	g.ui.OpenMenu([]foundation.MenuItem{
@@ -352,6 +363,24 @@ func (g *GameState) OpenWizardMenu() {
 				})
 			},
 		},
+		{
+			Name: "Show Patrol Routes",
+			Action: func() {
+				if len(g.currentMap().GetPatrolRoutes()) == 0 {
+					g.msg(foundation.Msg("No patrol routes on this map"))
+					return
+				}
+				g.ui.ShowMapOverlay(g.patrolRouteOverlay())
+			},
+			CloseMenus: true,
+		},
+		{
+			Name: "Show NPC Vision",
+			Action: func() {
+				g.ui.ShowMapOverlay(g.visionConeOverlay())
+			},
+			CloseMenus: true,
+		},
 		{
 			Name:   "Create Trap",
 			Action: g.openWizardCreateTrapMenu,
@@ -407,8 +436,10 @@ func (g *GameState) StartDialogue(name string, partner foundation.ChatterSource,
 		talkedFlagName := fmt.Sprintf("TalkedTo(%s)", npcName)
 		g.gameFlags.Increment(talkedFlagName)
 		params["NPC"] = actor
+		g.conversationPartner = actor
 	} else {
 		npcName = partner.Name()
+		g.conversationPartner = nil
 	}
 	params["NPC_NAME"] = npcName
 
@@ -521,6 +552,16 @@ func (g *GameState) OpenDialogueNode(conversation *Conversation, prevNode Conver
 						g.msg(foundation.HiLite("%s received.", itemStackName))
 					}
 
+				case "DoctorTreatment", "CureAddictions", "InstallImplants", "RemoveImplants":
+					price := args.GetInt(0)
+					doctor, _ := conversationPartner.(*Actor)
+					paidServices := map[string]func(*Actor, int){
+						"DoctorTreatment": g.doctorTreatment,
+						"CureAddictions":  g.doctorCureAddictions,
+						"InstallImplants": g.clinicInstallImplants,
+						"RemoveImplants":  g.clinicRemoveImplants,
+					}
+					paidServices[name](doctor, price)
 				default: // parse as generic expression and effect
 					expr, parseErr := govaluate.NewEvaluableExpressionWithFunctions(effect, g.getScriptFuncs())
 					if parseErr != nil {
@@ -25,6 +25,9 @@ func (g *GameState) Save(directory string) error {
 		"global":           {globalRecord},
 		"flags":            g.gameFlags.ToRecord(),
 		"terminal_guesses": g.terminalGuessesToRecords(),
+		"secrets":          g.foundSecretsToRecords(),
+		"alarms":           g.activeAlarmsToRecords(),
+		"map_times":        g.mapLeftAtToRecords(),
 	})
 	if err != nil {
 		return err
@@ -87,6 +90,9 @@ func (g *GameState) Load(directory string) {
 	}
 	g.logBuffer = make([]foundation.HiLiteString, 0)
 	g.terminalGuesses = g.terminalGuessesFromRecords(globalRecords["terminal_guesses"])
+	g.foundSecrets = g.foundSecretsFromRecords(globalRecords["secrets"])
+	g.activeAlarms = g.activeAlarmsFromRecords(globalRecords["alarms"])
+	g.mapLeftAt = g.mapLeftAtFromRecords(globalRecords["map_times"])
 
 	// Journal
 	journalFile := fxtools.MustOpen(path.Join(directory, "journal.rec"))
@@ -112,6 +118,12 @@ func (g *GameState) Load(directory string) {
 	}
 	g.activeMaps = loadedMaps
 
+	for _, gameMap := range loadedMaps {
+		for _, actor := range gameMap.Actors() {
+			actor.activeGoal = g.restoreGoal(actor.activeGoal)
+		}
+	}
+
 	filteredActors := g.currentMap().GetFilteredActors(func(actor *Actor) bool {
 		return actor.GetInternalName() == "player"
 	})
@@ -195,7 +195,7 @@ func (g *GameState) GetVisibleActors() []foundation.ActorForUI {
 
 func (g *GameState) GetVisibleEnemies() []*Actor {
 	return fxtools.FilterSlice(g.playerVisibleActorsByDistance(), func(actor *Actor) bool {
-		return actor.IsHostileTowards(g.Player)
+		return actor.IsEnemyOf(g.Player)
 	})
 }
 
@@ -277,6 +277,11 @@ func (g *GameState) GetInventoryForUI() []foundation.Item {
 	return g.Player.GetInventory().StackedItemsWithFilter(func(item foundation.Item) bool { return !item.IsAmmo() })
 }
 
+func (g *GameState) FieldsAt(loc geometry.Point) (fire, smoke, gas int) {
+	fields := g.currentMap().FieldsAt(loc)
+	return fields.Fire, fields.Smoke, fields.Gas
+}
+
 func (g *GameState) MapAt(loc geometry.Point) textiles.TextIcon {
 	if !g.currentMap().Contains(loc) {
 		return textiles.TextIcon{}
@@ -314,6 +319,10 @@ func (g *GameState) TopEntityAt(mapPos geometry.Point) foundation.EntityType {
 		if !object.IsHidden() {
 			return foundation.EntityTypeObject
 		}
+		// hidden doors are disguised as walls
+		if _, isDoor := object.(*Door); isDoor {
+			return foundation.EntityTypeObject
+		}
 	}
 
 	return foundation.EntityTypeWorldTile
@@ -29,6 +29,22 @@ func (g *GameState) NewObjectFromRecord(record recfile.Record, palette textiles.
 		return g.NewTerminal(record)
 	case "readable":
 		return g.NewReadable(record)
+	case "switch":
+		return g.NewSwitch(record)
+	case "alarmpanel":
+		return g.NewAlarmPanel(record)
+	case "bed":
+		return g.NewBed(record)
+	case "watersource":
+		return g.NewWaterSource(record)
+	case "pressureplate":
+		fallthrough
+	case "tripwire":
+		fallthrough
+	case "lasergrid":
+		fallthrough
+	case "gasvent":
+		return g.NewTrapFromRecord(record, foundation.ObjectCategoryFromString(objectType), newMap)
 	case "lockeddoor":
 		fallthrough
 	case "closeddoor":
@@ -0,0 +1,225 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/gridmap"
+	"RogueUI/special"
+	"fmt"
+	"github.com/memmaker/go/geometry"
+	"strings"
+	"time"
+)
+
+// The survival needs are only tracked, when the survival mode is enabled in the config.
+// Hunger is always on and handled in removeDeadAndApplyRegeneration.
+const (
+	turnsPerHour              = 360
+	thirstInterval            = 1000
+	fatigueInterval           = 2000
+	maxNeedStage              = 3
+	dehydrationDamageInterval = 50
+	irradiatedWaterDamage     = 2
+)
+
+// survivalNeed grows by one stage per interval. Every stage comes with its own penalties.
+type survivalNeed struct {
+	stageFlag   foundation.ActorFlag
+	counterFlag foundation.ActorFlag
+	interval    int
+	stageNames  [maxNeedStage]string
+	penalties   [maxNeedStage]map[special.Stat]int
+}
+
+var survivalNeeds = []survivalNeed{
+	{
+		stageFlag:   foundation.FlagThirst,
+		counterFlag: foundation.FlagTurnsSinceDrinking,
+		interval:    thirstInterval,
+		stageNames:  [maxNeedStage]string{"Thirsty", "Parched", "Dehydrated"},
+		penalties: [maxNeedStage]map[special.Stat]int{
+			{special.Agility: -1},
+			{special.Agility: -1, special.Endurance: -1},
+			{special.Agility: -2, special.Endurance: -2, special.Strength: -1},
+		},
+	},
+	{
+		stageFlag:   foundation.FlagFatigue,
+		counterFlag: foundation.FlagTurnsSinceSleeping,
+		interval:    fatigueInterval,
+		stageNames:  [maxNeedStage]string{"Tired", "Exhausted", "Sleep Deprived"},
+		penalties: [maxNeedStage]map[special.Stat]int{
+			{special.Perception: -1},
+			{special.Perception: -2, special.Agility: -1},
+			{special.Perception: -2, special.Agility: -2, special.Intelligence: -2},
+		},
+	},
+}
+
+func (g *GameState) updateSurvivalNeeds() {
+	flags := g.Player.GetFlags()
+	for _, need := range survivalNeeds {
+		flags.Increment(need.counterFlag)
+		g.updateNeedStage(need)
+	}
+
+	if flags.Get(foundation.FlagThirst) >= maxNeedStage && flags.Get(foundation.FlagTurnsSinceDrinking)%dehydrationDamageInterval == 0 {
+		damage := SourcedDamage{
+			NameOfThing:     "dehydration",
+			IsObviousAttack: false,
+			TargetingMode:   special.TargetingModeFireSingle,
+			DamageType:      special.DamageTypeNormal,
+			DamageAmount:    1,
+			BodyPart:        special.Body,
+		}
+		g.ui.AddAnimations(g.damageActor(damage, g.Player))
+	}
+}
+
+// updateNeedStage derives the stage from the counter and tells the player, when it got worse.
+func (g *GameState) updateNeedStage(need survivalNeed) {
+	flags := g.Player.GetFlags()
+	stage := min(maxNeedStage, flags.Get(need.counterFlag)/need.interval)
+	oldStage := flags.Get(need.stageFlag)
+	if stage == oldStage {
+		return
+	}
+	flags.Unset(need.stageFlag)
+	if stage == 0 {
+		return
+	}
+	flags.Increase(need.stageFlag, stage)
+	if stage > oldStage {
+		g.msg(foundation.HiLite("You are %s.", strings.ToLower(need.stageNames[stage-1])))
+	}
+}
+
+// GetSurvivalStatModifiers are the penalties of the current thirst and fatigue stages.
+func (a *Actor) GetSurvivalStatModifiers(stat special.Stat) []special.Modifier {
+	var result []special.Modifier
+	for _, need := range survivalNeeds {
+		stage := min(maxNeedStage, a.GetFlags().Get(need.stageFlag))
+		if stage == 0 {
+			continue
+		}
+		if value, exists := need.penalties[stage-1][stat]; exists {
+			result = append(result, special.DefaultModifier{Source: need.stageNames[stage-1], Modifier: value, Order: 1})
+		}
+	}
+	return result
+}
+
+func (g *GameState) playerDrink(isIrradiated bool) {
+	flags := g.Player.GetFlags()
+	flags.Unset(foundation.FlagTurnsSinceDrinking)
+	flags.Unset(foundation.FlagThirst)
+	if isIrradiated {
+		g.msg(foundation.Msg("You drink the water. It tastes like metal."))
+		damage := SourcedDamage{
+			NameOfThing:     "irradiated water",
+			IsObviousAttack: false,
+			TargetingMode:   special.TargetingModeFireSingle,
+			DamageType:      special.DamageTypeRadiation,
+			DamageAmount:    irradiatedWaterDamage,
+			BodyPart:        special.Body,
+		}
+		g.ui.AddAnimations(g.damageActor(damage, g.Player))
+	} else {
+		g.msg(foundation.Msg("You drink the water. It is cool and refreshing."))
+	}
+	g.endPlayerTurn(g.Player.timeNeededForActions())
+}
+
+// appendContextActionsForTile lets the player drink from puddles, rivers and the like.
+func (g *GameState) appendContextActionsForTile(items []foundation.MenuItem, mapPos geometry.Point) []foundation.MenuItem {
+	if !g.currentMap().IsTileWithFlagAt(mapPos, gridmap.TileFlagWater) {
+		return items
+	}
+	isIrradiated := g.currentMap().IsTileWithFlagAt(mapPos, gridmap.TileFlagRadiated)
+	return append(items, foundation.MenuItem{
+		Name: "Drink",
+		Action: func() {
+			g.playerDrink(isIrradiated)
+		},
+		CloseMenus: true,
+	})
+}
+
+func (g *GameState) isPlayerNextToBed() bool {
+	currentMap := g.currentMap()
+	for _, neighbor := range currentMap.NeighborsAll(g.Player.Position(), currentMap.Contains) {
+		if currentMap.IsObjectAt(neighbor) && currentMap.ObjectAt(neighbor).GetCategory() == foundation.ObjectBed {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *GameState) OpenSleepMenu() {
+	sleepFor := func(hours int) foundation.MenuItem {
+		name := fmt.Sprintf("Sleep for %d hours", hours)
+		if hours == 1 {
+			name = "Sleep for an hour"
+		}
+		return foundation.MenuItem{
+			Name: name,
+			Action: func() {
+				g.PlayerSleep(time.Duration(hours) * time.Hour)
+			},
+			CloseMenus: true,
+		}
+	}
+	g.ui.OpenMenu([]foundation.MenuItem{
+		sleepFor(1),
+		sleepFor(2),
+		sleepFor(4),
+		sleepFor(8),
+		{
+			Name: "Sleep until morning (0600)",
+			Action: func() {
+				now := g.gameTime.Time
+				morning := time.Date(now.Year(), now.Month(), now.Day(), 6, 0, 0, 0, now.Location())
+				if now.After(morning) {
+					morning = morning.AddDate(0, 0, 1)
+				}
+				g.PlayerSleep(morning.Sub(now))
+			},
+			CloseMenus: true,
+		},
+	})
+}
+
+// PlayerSleep removes fatigue and heals, but the player gets thirsty in the meantime.
+func (g *GameState) PlayerSleep(duration time.Duration) {
+	for _, actor := range g.playerVisibleActorsByDistance() {
+		if actor.IsEnemyOf(g.Player) {
+			g.msg(foundation.Msg("You can't sleep with enemies nearby."))
+			return
+		}
+	}
+	hours := int(duration.Hours())
+
+	g.ui.FadeToBlack()
+	g.advanceTime(duration)
+
+	flags := g.Player.GetFlags()
+	turnsSinceSleeping := flags.Get(foundation.FlagTurnsSinceSleeping)
+	flags.Decrease(foundation.FlagTurnsSinceSleeping, min(turnsSinceSleeping, hours*fatigueInterval/2))
+	if g.config.SurvivalMode {
+		flags.Increase(foundation.FlagTurnsSinceDrinking, hours*turnsPerHour/2)
+	}
+	for _, need := range survivalNeeds {
+		g.updateNeedStage(need)
+	}
+
+	g.Player.Heal(hours * max(1, g.Player.GetCharSheet().GetStat(special.Endurance)/2))
+
+	if flags.Get(foundation.FlagFatigue) == 0 {
+		g.msg(foundation.Msg("You wake up feeling rested."))
+	} else {
+		g.msg(foundation.Msg("You wake up, but you are still tired."))
+	}
+	if g.Player.GetEquipment().HasWatch() {
+		g.msg(foundation.Msg(fmt.Sprintf("Time is now %s", g.gameTime.Time.Format("15:04"))))
+	}
+	g.ui.FadeFromBlack()
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"github.com/memmaker/go/geometry"
+)
+
+const targetSearchRadius = 10
+
+// isOnSameSide is true for allies and for hostile NPCs without a team, who don't fight each other.
+func isOnSameSide(one, two *Actor) bool {
+	if isAllyOf(one, two) {
+		return true
+	}
+	return one.GetTeam() == "" && two.GetTeam() == "" &&
+		one.GetState() == foundation.AttackEverything && two.GetState() == foundation.AttackEverything
+}
+
+// isValidTarget checks if the actor would fight the other one.
+// Grudges against actors and teams always count, actors that attack everything spare their own side.
+func (g *GameState) isValidTarget(actor, other *Actor) bool {
+	if other == actor || !other.IsAlive() || other.IsSurrendered() || !actor.WantsToAttack(other) {
+		return false
+	}
+	if actor.enemyActors[other.GetInternalName()] || (other.GetTeam() != "" && actor.enemyTeams[other.GetTeam()]) {
+		return true
+	}
+	return other == g.Player || !isOnSameSide(actor, other)
+}
+
+// selectTarget returns the closest actor the given actor can see and wants to fight, or nil.
+func (g *GameState) selectTarget(actor *Actor) *Actor {
+	if actor.GetState() != foundation.AttackEverything && actor.GetState() != foundation.AttackEnemies {
+		return nil
+	}
+	visionRange := actor.GetVisionRange()
+	candidates := g.currentMap().GetFilteredActorsInRadius(actor.Position(), visionRange, func(other *Actor) bool {
+		return g.isValidTarget(actor, other) && g.isInVisionOf(actor, other.Position()) && CanPerceive(actor, other)
+	})
+	var target *Actor
+	closestDistance := visionRange + 1
+	for _, candidate := range candidates {
+		distance := geometry.DistanceChebyshev(actor.Position(), candidate.Position())
+		if distance < closestDistance {
+			target = candidate
+			closestDistance = distance
+		}
+	}
+	if target != nil {
+		actor.SetFacing(directionTowards(actor.Position(), target.Position()))
+	}
+	return target
+}
+
+// alertAllies makes the allies of the victim that saw the attack join the fight against the attacker.
+func (g *GameState) alertAllies(victim *Actor, attacker *Actor) {
+	if attacker == nil || attacker == victim {
+		return
+	}
+	allies := g.currentMap().GetFilteredActorsInRadius(victim.Position(), targetSearchRadius, func(other *Actor) bool {
+		return other != g.Player && other != attacker && other.IsAlive() &&
+			isAllyOf(other, victim) && !isAllyOf(other, attacker) &&
+			g.isInVisionOf(other, victim.Position())
+	})
+	for _, ally := range allies {
+		g.trySetHostile(ally, attacker)
+	}
+}
@@ -0,0 +1,402 @@
+package game
+
+import (
+	"RogueUI/foundation"
+	"RogueUI/special"
+	"fmt"
+	"github.com/memmaker/go/fxtools"
+	"strconv"
+	"strings"
+)
+
+// openTerminalSession shows the command line of a terminal.
+func (g *GameState) openTerminalSession(t *Terminal) {
+	g.ui.OpenTerminal(t.title, g.terminalGreeting(t), func(command string) []string {
+		return g.executeTerminalCommand(t, command)
+	})
+}
+
+func (g *GameState) terminalGreeting(t *Terminal) []string {
+	if t.isLockedOut && !t.HasAccess() {
+		return []string{"TERMINAL LOCKED", "Please contact an administrator."}
+	}
+	if !t.HasAccess() {
+		return []string{
+			"ACCESS RESTRICTED",
+			"Type LOGIN to enter a password, HACK or OVERRIDE to bypass the security.",
+		}
+	}
+	return []string{"Welcome, user.", "Type HELP for a list of commands."}
+}
+
+func (g *GameState) terminalID(t *Terminal) string {
+	return fmt.Sprintf("%s/%s", g.currentMap().GetName(), t.Position().Encode())
+}
+
+func (g *GameState) executeTerminalCommand(t *Terminal, input string) []string {
+	words := strings.Fields(strings.ToLower(input))
+	if len(words) == 0 {
+		return nil
+	}
+	command, args := words[0], words[1:]
+
+	switch command {
+	case "help":
+		return g.terminalHelp(t)
+	case "logout", "exit", "quit":
+		g.ui.CloseTerminal()
+		return nil
+	}
+
+	if !t.HasAccess() {
+		switch command {
+		case "login":
+			return g.terminalLogin(t)
+		case "hack":
+			return g.terminalHack(t)
+		case "override":
+			return g.terminalOverride(t)
+		}
+		return []string{"ACCESS DENIED"}
+	}
+
+	switch {
+	case command == "logs" && len(t.messages) > 0:
+		return g.terminalListLogs(t)
+	case command == "read" && len(t.messages) > 0:
+		return g.terminalReadLog(t, args)
+	case command == "doors" && len(t.doors) > 0:
+		return g.terminalListDoors(t)
+	case (command == "lock" || command == "unlock") && len(t.doors) > 0:
+		return g.terminalLockDoor(t, command == "lock", args)
+	case command == "lights" && len(t.lights) > 0:
+		return g.terminalLights(t, args)
+	case command == "alarm" && len(t.alarms) > 0:
+		return g.terminalAlarm(t, args)
+	case command == "turrets" && len(t.turrets) > 0:
+		return g.terminalListTurrets(t)
+	case command == "turret" && len(t.turrets) > 0:
+		return g.terminalRetargetTurret(t, args)
+	case command == "floors" && len(t.elevators) > 0:
+		return g.terminalListFloors(t)
+	case command == "floor" && len(t.elevators) > 0:
+		return g.terminalLockFloor(t, args)
+	}
+	return []string{fmt.Sprintf("Unknown command: %s", command)}
+}
+
+func (g *GameState) terminalHelp(t *Terminal) []string {
+	if !t.HasAccess() {
+		return []string{
+			"LOGIN - log in with a password",
+			"HACK - try to guess the password",
+			"OVERRIDE - bypass the security system",
+			"LOGOUT - leave the terminal",
+		}
+	}
+	var help []string
+	if len(t.messages) > 0 {
+		help = append(help, "LOGS - list the stored messages", "READ <n> - read a message")
+	}
+	if len(t.doors) > 0 {
+		help = append(help, "DOORS - list the connected doors", "LOCK <n> / UNLOCK <n> - lock or unlock a door")
+	}
+	if len(t.lights) > 0 {
+		help = append(help, "LIGHTS [ON|OFF] - show or switch the lighting")
+	}
+	if len(t.alarms) > 0 {
+		help = append(help, "ALARM [ON|OFF] - show or switch the alarm system")
+	}
+	if len(t.turrets) > 0 {
+		help = append(help, "TURRETS - list the connected turrets", "TURRET <n> OFF|INTRUDERS|FRIENDLY - set the targeting mode")
+	}
+	if len(t.elevators) > 0 {
+		help = append(help, "FLOORS - list the elevator floors", "FLOOR <n> LOCK|UNLOCK - secure or release a floor")
+	}
+	return append(help, "LOGOUT - leave the terminal")
+}
+
+func (g *GameState) terminalLogin(t *Terminal) []string {
+	if t.passwordItem == "" || !g.Player.GetInventory().HasItemWithName(t.passwordItem) {
+		return []string{"No valid password found."}
+	}
+	t.hasAccess = true
+	t.isLockedOut = false
+	return []string{"Password accepted.", "Type HELP for a list of commands."}
+}
+
+// terminalHack closes the command line for the hacking minigame and reopens it afterward.
+func (g *GameState) terminalHack(t *Terminal) []string {
+	if t.isLockedOut {
+		return []string{"TERMINAL LOCKED"}
+	}
+	id := g.terminalID(t)
+	g.ui.CloseTerminal()
+	g.ui.StartHackingGame(uint64(fxtools.StringSum(id)), t.accessDiff, g.terminalGuesses[id], func(previousGuesses []string, result foundation.InteractionResult) {
+		g.terminalGuesses[id] = previousGuesses
+		switch result {
+		case foundation.Success:
+			t.hasAccess = true
+			g.msg(foundation.Msg("You hacked the terminal"))
+			g.learnByDoing(special.Technology, learnGainTask, t.accessDiff.GetRollModifier())
+		case foundation.Failure:
+			t.isLockedOut = true
+			g.msg(foundation.Msg("The terminal locked you out"))
+		}
+		g.openTerminalSession(t)
+	})
+	return nil
+}
+
+func (g *GameState) terminalOverride(t *Terminal) []string {
+	if t.isLockedOut {
+		return []string{"TERMINAL LOCKED"}
+	}
+	if g.playerHackingRoll(t.accessDiff).Success {
+		t.hasAccess = true
+		g.learnByDoing(special.Technology, learnGainTask, t.accessDiff.GetRollModifier())
+		return []string{"Security override accepted.", "Type HELP for a list of commands."}
+	}
+	t.isLockedOut = true
+	return []string{"Security violation detected.", "TERMINAL LOCKED"}
+}
+
+// terminalIndexArg parses a one-based list index from the arguments.
+func terminalIndexArg(args []string, count int) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	index, err := strconv.Atoi(args[0])
+	if err != nil || index < 1 || index > count {
+		return 0, false
+	}
+	return index - 1, true
+}
+
+func (g *GameState) terminalListLogs(t *Terminal) []string {
+	var lines []string
+	for i, message := range t.messages {
+		lines = append(lines, fmt.Sprintf("[%d] %s", i+1, message.Subject))
+	}
+	return lines
+}
+
+func (g *GameState) terminalReadLog(t *Terminal, args []string) []string {
+	index, ok := terminalIndexArg(args, len(t.messages))
+	if !ok {
+		return []string{"Usage: READ <n>"}
+	}
+	message := t.messages[index]
+	lines := []string{fmt.Sprintf("== %s ==", message.Subject)}
+	return append(lines, strings.Split(message.Text, "\n")...)
+}
+
+func (g *GameState) terminalListDoors(t *Terminal) []string {
+	var lines []string
+	for i, doorName := range t.doors {
+		door, exists := g.TryGetDoorByName(doorName)
+		status := "NO CONNECTION"
+		if exists {
+			switch {
+			case door.IsBroken():
+				status = "BROKEN"
+			case door.IsLocked():
+				status = "LOCKED"
+			case door.IsOpen():
+				status = "OPEN"
+			default:
+				status = "CLOSED"
+			}
+		}
+		lines = append(lines, fmt.Sprintf("[%d] %s - %s", i+1, doorName, status))
+	}
+	return lines
+}
+
+func (g *GameState) terminalLockDoor(t *Terminal, lock bool, args []string) []string {
+	index, ok := terminalIndexArg(args, len(t.doors))
+	if !ok {
+		return []string{"Usage: LOCK <n> / UNLOCK <n>"}
+	}
+	door, exists := g.TryGetDoorByName(t.doors[index])
+	if !exists || door.IsBroken() {
+		return []string{"NO CONNECTION"}
+	}
+	if !lock {
+		if door.IsLocked() {
+			door.Unlock()
+		}
+		return []string{fmt.Sprintf("%s unlocked.", t.doors[index])}
+	}
+	if g.currentMap().IsActorAt(door.Position()) {
+		return []string{"Door obstructed."}
+	}
+	door.Lock()
+	return []string{fmt.Sprintf("%s locked.", t.doors[index])}
+}
+
+func (g *GameState) terminalLights(t *Terminal, args []string) []string {
+	if len(args) == 0 {
+		status := "OFF"
+		for _, light := range t.lights {
+			if g.currentMap().IsBakedLightEnabled(light) {
+				status = "ON"
+				break
+			}
+		}
+		return []string{fmt.Sprintf("Lighting: %s", status)}
+	}
+	if args[0] != "on" && args[0] != "off" {
+		return []string{"Usage: LIGHTS [ON|OFF]"}
+	}
+	for _, light := range t.lights {
+		g.currentMap().SetBakedLightEnabled(light, args[0] == "on")
+	}
+	g.updatePlayerFoVAndApplyExploration()
+	return []string{fmt.Sprintf("Lighting switched %s.", args[0])}
+}
+
+func (g *GameState) terminalAlarm(t *Terminal, args []string) []string {
+	if len(args) == 0 {
+		status := "INACTIVE"
+		for _, alarm := range t.alarms {
+			if g.IsAlarmActive(alarm) {
+				status = "ACTIVE"
+				break
+			}
+		}
+		return []string{fmt.Sprintf("Alarm system: %s", status)}
+	}
+	switch args[0] {
+	case "on":
+		for _, alarm := range t.alarms {
+			g.triggerAlarm(alarm, g.Player, t.Position())
+		}
+		return []string{"Alarm system activated."}
+	case "off":
+		for _, alarm := range t.alarms {
+			g.endAlarm(alarm)
+		}
+		return []string{"Alarm system deactivated."}
+	}
+	return []string{"Usage: ALARM [ON|OFF]"}
+}
+
+func (g *GameState) turretsWithName(name string) []*Actor {
+	return g.currentMap().GetFilteredActors(func(a *Actor) bool {
+		return a.GetInternalName() == name && a.IsAlive()
+	})
+}
+
+func (g *GameState) terminalListTurrets(t *Terminal) []string {
+	var lines []string
+	for i, turretName := range t.turrets {
+		turrets := g.turretsWithName(turretName)
+		status := "OFFLINE"
+		if len(turrets) > 0 {
+			turret := turrets[0]
+			switch {
+			case turret.HasFlag(foundation.FlagShutDown):
+				status = "SHUT DOWN"
+			case turret.IsAlliedWith(g.Player):
+				status = "FRIENDLY"
+			case turret.IsEnemyOf(g.Player):
+				status = "TARGETING INTRUDERS"
+			default:
+				status = "STANDBY"
+			}
+		}
+		lines = append(lines, fmt.Sprintf("[%d] %s (%d online) - %s", i+1, turretName, len(turrets), status))
+	}
+	return lines
+}
+
+func (g *GameState) terminalRetargetTurret(t *Terminal, args []string) []string {
+	index, ok := terminalIndexArg(args, len(t.turrets))
+	if !ok || len(args) < 2 {
+		return []string{"Usage: TURRET <n> OFF|INTRUDERS|FRIENDLY"}
+	}
+	turrets := g.turretsWithName(t.turrets[index])
+	if len(turrets) == 0 {
+		return []string{"NO CONNECTION"}
+	}
+	for _, turret := range turrets {
+		switch args[1] {
+		case "off":
+			turret.GetFlags().Set(foundation.FlagShutDown)
+			turret.SetNeutral()
+			turret.SetGoal(NoGoal)
+		case "intruders":
+			turret.GetFlags().Unset(foundation.FlagShutDown)
+			if turret.IsAlliedWith(g.Player) {
+				turret.SetTeam("")
+			}
+			turret.SetHostileTowards(g.Player)
+		case "friendly":
+			g.reprogramToFriendly(turret)
+		default:
+			return []string{"Usage: TURRET <n> OFF|INTRUDERS|FRIENDLY"}
+		}
+	}
+	return []string{fmt.Sprintf("%s set to %s.", t.turrets[index], strings.ToUpper(args[1]))}
+}
+
+func (g *GameState) tryGetElevatorByIdentifier(identifier string) (*Elevator, bool) {
+	for _, obj := range g.currentMap().Objects() {
+		if elevator, isElevator := obj.(*Elevator); isElevator && elevator.GetIdentifier() == identifier {
+			return elevator, true
+		}
+	}
+	return nil, false
+}
+
+// terminalFloors lists the floors of all linked elevators that can be locked.
+func (g *GameState) terminalFloors(t *Terminal) []ElevatorButton {
+	var floors []ElevatorButton
+	for _, identifier := range t.elevators {
+		elevator, exists := g.tryGetElevatorByIdentifier(identifier)
+		if !exists {
+			continue
+		}
+		for _, level := range elevator.GetLevels() {
+			if level.LockFlag != "" {
+				floors = append(floors, level)
+			}
+		}
+	}
+	return floors
+}
+
+func (g *GameState) terminalListFloors(t *Terminal) []string {
+	floors := g.terminalFloors(t)
+	if len(floors) == 0 {
+		return []string{"NO CONNECTION"}
+	}
+	var lines []string
+	for i, floor := range floors {
+		status := "RELEASED"
+		if g.gameFlags.HasFlag(floor.LockFlag) {
+			status = "LOCKED"
+		}
+		lines = append(lines, fmt.Sprintf("[%d] %s - %s", i+1, floor.Label, status))
+	}
+	return lines
+}
+
+func (g *GameState) terminalLockFloor(t *Terminal, args []string) []string {
+	floors := g.terminalFloors(t)
+	index, ok := terminalIndexArg(args, len(floors))
+	if !ok || len(args) < 2 {
+		return []string{"Usage: FLOOR <n> LOCK|UNLOCK"}
+	}
+	floor := floors[index]
+	switch args[1] {
+	case "lock":
+		g.gameFlags.SetFlag(floor.LockFlag)
+		return []string{fmt.Sprintf("%s locked.", floor.Label)}
+	case "unlock":
+		g.gameFlags.ClearFlag(floor.LockFlag)
+		return []string{fmt.Sprintf("%s released.", floor.Label)}
+	}
+	return []string{"Usage: FLOOR <n> LOCK|UNLOCK"}
+}
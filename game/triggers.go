@@ -0,0 +1,129 @@
+package game
+
+import (
+	"RogueUI/gridmap"
+	"github.com/Knetic/govaluate"
+	"github.com/memmaker/go/fxtools"
+	"github.com/memmaker/go/geometry"
+)
+
+// triggerOccupants tracks, per trigger name, the actors inside the region
+// and for how many turns they have been there.
+type triggerOccupants map[string]map[*Actor]int
+
+func (t triggerOccupants) add(triggerName string, actor *Actor) {
+	if _, exists := t[triggerName]; !exists {
+		t[triggerName] = make(map[*Actor]int)
+	}
+	t[triggerName][actor] = 0
+}
+
+func (t triggerOccupants) remove(triggerName string, actor *Actor) {
+	delete(t[triggerName], actor)
+}
+
+// resetTriggerOccupants is called after entering a map.
+// Actors already standing inside a region are counted as occupants without firing anything.
+func (g *GameState) resetTriggerOccupants() {
+	g.triggerOccupants = make(triggerOccupants)
+	for _, actor := range g.currentMap().Actors() {
+		for _, trigger := range g.currentMap().GetNamedTriggersAt(actor.Position()) {
+			if g.triggerAppliesTo(trigger, actor) {
+				g.triggerOccupants.add(trigger.Name, actor)
+			}
+		}
+	}
+}
+
+// checkTriggersAfterMovement fires the enter and leave actions of all regions the actor crossed.
+func (g *GameState) checkTriggersAfterMovement(actor *Actor, oldPos, newPos geometry.Point) {
+	for name, trigger := range g.currentMap().GetNamedTriggers() {
+		if !g.triggerAppliesTo(trigger, actor) {
+			continue
+		}
+		wasInside := trigger.Bounds.Contains(oldPos)
+		isInside := trigger.Bounds.Contains(newPos)
+		if !wasInside && isInside {
+			g.triggerOccupants.add(name, actor)
+			g.fireTrigger(trigger, trigger.OnEnter, actor)
+		} else if wasInside && !isInside {
+			g.triggerOccupants.remove(name, actor)
+			g.fireTrigger(trigger, trigger.OnLeave, actor)
+		}
+	}
+}
+
+// updateTriggers counts the turns every actor spent inside a region
+// and fires the dwell actions once the required number of turns has passed.
+func (g *GameState) updateTriggers() {
+	for name, trigger := range g.currentMap().GetNamedTriggers() {
+		for actor, turns := range g.triggerOccupants[name] {
+			if !actor.IsAlive() || !trigger.Bounds.Contains(actor.Position()) {
+				g.triggerOccupants.remove(name, actor)
+				continue
+			}
+			turns++
+			g.triggerOccupants[name][actor] = turns
+			if turns == trigger.DwellTurns {
+				g.fireTrigger(trigger, trigger.OnDwell, actor)
+				// a one shot trigger is spent for the other occupants, too
+				trigger = g.currentMap().GetNamedTriggers()[name]
+				if !trigger.IsActive() {
+					break
+				}
+			}
+		}
+	}
+}
+
+func (g *GameState) triggerAppliesTo(trigger gridmap.Trigger, actor *Actor) bool {
+	switch trigger.Actor {
+	case "", "player":
+		return actor == g.Player
+	case "npc":
+		return actor != g.Player
+	case "any":
+		return true
+	}
+	return actor.GetInternalName() == trigger.Actor
+}
+
+func (g *GameState) fireTrigger(trigger gridmap.Trigger, actions []string, actor *Actor) {
+	if len(actions) == 0 || !trigger.IsActive() {
+		return
+	}
+	variables := map[string]interface{}{
+		"actor":   actor.GetInternalName(),
+		"trigger": trigger.Name,
+	}
+	if trigger.Condition != "" && !g.evaluateTriggerCondition(trigger.Condition, variables) {
+		return
+	}
+	if trigger.OneShot {
+		g.currentMap().SetTriggerFired(trigger.Name)
+	}
+	for _, action := range actions {
+		if fxtools.LooksLikeAFunction(action) {
+			g.evaluateTriggerExpression(action, variables)
+		} else {
+			g.RunScriptByName(action)
+		}
+	}
+}
+
+func (g *GameState) evaluateTriggerCondition(condition string, variables map[string]interface{}) bool {
+	result, isBool := g.evaluateTriggerExpression(condition, variables).(bool)
+	return isBool && result
+}
+
+func (g *GameState) evaluateTriggerExpression(expression string, variables map[string]interface{}) interface{} {
+	expr, parseErr := govaluate.NewEvaluableExpressionWithFunctions(expression, g.getScriptFuncs())
+	if parseErr != nil {
+		panic(parseErr)
+	}
+	result, evalErr := expr.Evaluate(variables)
+	if evalErr != nil {
+		panic(evalErr)
+	}
+	return result
+}
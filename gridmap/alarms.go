@@ -0,0 +1,98 @@
+package gridmap
+
+import (
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DefaultAlarmTimeout is the number of turns without a sighting of the player after which an alarm is called off.
+const DefaultAlarmTimeout = 50
+
+// AlarmWave is a group of reinforcements that arrives at a named location some turns after the alarm went off.
+type AlarmWave struct {
+	Delay    int
+	Actors   []string
+	Location string
+}
+
+// Alarm is the security response of a map.
+// While it's active, the listed doors are locked, the lights are on and the alarm music plays.
+// The alarm is called off after Timeout turns without anyone of the responding team seeing the player.
+type Alarm struct {
+	Name      string
+	Team      string
+	Waves     []AlarmWave
+	LockDoors []string
+	Lights    []geometry.Point
+	MusicFile string
+	Timeout   int
+}
+
+// NewAlarmFromRecord reads an alarm from the objects of a map.
+// A wave is the delay in turns, the actors from the reinforcements of the map and the named location they arrive at, eg.
+//
+//	Wave: 5 lab_guard|lab_guard at lab_entrance
+func NewAlarmFromRecord(record recfile.Record) Alarm {
+	alarm := Alarm{Timeout: DefaultAlarmTimeout}
+	for _, field := range record {
+		switch strings.ToLower(field.Name) {
+		case "identifier", "name":
+			alarm.Name = field.Value
+		case "team":
+			alarm.Team = field.Value
+		case "wave":
+			alarm.Waves = append(alarm.Waves, newAlarmWaveFromString(field.Value))
+		case "lockdoor":
+			alarm.LockDoors = append(alarm.LockDoors, field.Value)
+		case "light":
+			pos, _ := geometry.NewPointFromEncodedString(field.Value)
+			alarm.Lights = append(alarm.Lights, pos)
+		case "music":
+			alarm.MusicFile = field.Value
+		case "timeout":
+			alarm.Timeout = field.AsInt()
+		}
+	}
+	slices.SortStableFunc(alarm.Waves, func(a, b AlarmWave) int {
+		return a.Delay - b.Delay
+	})
+	return alarm
+}
+
+func newAlarmWaveFromString(value string) AlarmWave {
+	var wave AlarmWave
+	actors, location, _ := strings.Cut(value, " at ")
+	wave.Location = strings.TrimSpace(location)
+	parts := strings.Fields(actors)
+	if len(parts) == 0 {
+		return wave
+	}
+	if delay, err := strconv.Atoi(parts[0]); err == nil {
+		wave.Delay = delay
+		parts = parts[1:]
+	}
+	for _, part := range parts {
+		for _, actorName := range strings.Split(part, "|") {
+			if actorName != "" {
+				wave.Actors = append(wave.Actors, actorName)
+			}
+		}
+	}
+	return wave
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) AddAlarm(alarm Alarm) {
+	m.namedAlarms[alarm.Name] = alarm
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) GetAlarm(name string) (Alarm, bool) {
+	alarm, exists := m.namedAlarms[name]
+	return alarm, exists
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) GetAlarms() map[string]Alarm {
+	return m.namedAlarms
+}
@@ -0,0 +1,59 @@
+package gridmap
+
+import (
+	"github.com/memmaker/go/recfile"
+	"slices"
+	"testing"
+)
+
+func TestNewAlarmWaveFromString(t *testing.T) {
+	tests := []struct {
+		value        string
+		wantDelay    int
+		wantActors   []string
+		wantLocation string
+	}{
+		{"10 police_officer|police_officer at spawn", 10, []string{"police_officer", "police_officer"}, "spawn"},
+		{"25 police_officer at taxi_stand", 25, []string{"police_officer"}, "taxi_stand"},
+		{"5 lab_guard lab_guard at lab_entrance", 5, []string{"lab_guard", "lab_guard"}, "lab_entrance"},
+		{"lab_guard at lab_entrance", 0, []string{"lab_guard"}, "lab_entrance"},
+		{"3 lab_guard||robot", 3, []string{"lab_guard", "robot"}, ""},
+		{"", 0, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			wave := newAlarmWaveFromString(tt.value)
+			if wave.Delay != tt.wantDelay {
+				t.Errorf("Delay = %d, want %d", wave.Delay, tt.wantDelay)
+			}
+			if !slices.Equal(wave.Actors, tt.wantActors) {
+				t.Errorf("Actors = %v, want %v", wave.Actors, tt.wantActors)
+			}
+			if wave.Location != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", wave.Location, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestNewAlarmFromRecordSortsWaves(t *testing.T) {
+	alarm := NewAlarmFromRecord(recfile.Record{
+		{Name: "Identifier", Value: "store"},
+		{Name: "Team", Value: "store_security"},
+		{Name: "Wave", Value: "25 police_officer at taxi_stand"},
+		{Name: "Wave", Value: "10 police_officer|police_officer at spawn"},
+		{Name: "LockDoor", Value: "back_door"},
+	})
+	if alarm.Name != "store" || alarm.Team != "store_security" {
+		t.Errorf("unexpected name or team %q/%q", alarm.Name, alarm.Team)
+	}
+	if alarm.Timeout != DefaultAlarmTimeout {
+		t.Errorf("Timeout = %d, want %d", alarm.Timeout, DefaultAlarmTimeout)
+	}
+	if len(alarm.Waves) != 2 || alarm.Waves[0].Delay != 10 || alarm.Waves[1].Delay != 25 {
+		t.Errorf("waves are not sorted by delay: %+v", alarm.Waves)
+	}
+	if !slices.Equal(alarm.LockDoors, []string{"back_door"}) {
+		t.Errorf("LockDoors = %v", alarm.LockDoors)
+	}
+}
@@ -0,0 +1,221 @@
+package gridmap
+
+import (
+	"github.com/memmaker/go/geometry"
+	"math/rand"
+)
+
+type FieldType uint8
+
+const (
+	FieldFire FieldType = iota
+	FieldSmoke
+	FieldGas
+)
+
+func (f FieldType) String() string {
+	switch f {
+	case FieldFire:
+		return "fire"
+	case FieldSmoke:
+		return "smoke"
+	case FieldGas:
+		return "gas"
+	}
+	return "unknown"
+}
+
+const MaxFieldIntensity = 10
+
+// SmokeOpaqueIntensity is the smoke density at which a cell starts to block sight
+const SmokeOpaqueIntensity = 3
+
+// Fields holds the intensity of the environmental effects on a single cell.
+// An intensity of zero means the effect is not present.
+type Fields struct {
+	Fire  int
+	Smoke int
+	Gas   int
+}
+
+func (f Fields) IsEmpty() bool {
+	return f.Fire <= 0 && f.Smoke <= 0 && f.Gas <= 0
+}
+
+func (f Fields) Get(fieldType FieldType) int {
+	switch fieldType {
+	case FieldFire:
+		return f.Fire
+	case FieldSmoke:
+		return f.Smoke
+	case FieldGas:
+		return f.Gas
+	}
+	return 0
+}
+
+func (f Fields) With(fieldType FieldType, intensity int) Fields {
+	intensity = max(0, min(MaxFieldIntensity, intensity))
+	switch fieldType {
+	case FieldFire:
+		f.Fire = intensity
+	case FieldSmoke:
+		f.Smoke = intensity
+	case FieldGas:
+		f.Gas = intensity
+	}
+	return f
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) AddField(pos geometry.Point, fieldType FieldType, intensity int) {
+	if !m.Contains(pos) || intensity <= 0 {
+		return
+	}
+	if fieldType == FieldFire && m.IsTileWithFlagAt(pos, TileFlagWater) {
+		return
+	}
+	current := m.fields[pos]
+	m.setFields(pos, current.With(fieldType, current.Get(fieldType)+intensity))
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) RemoveField(pos geometry.Point, fieldType FieldType) {
+	current, exists := m.fields[pos]
+	if !exists {
+		return
+	}
+	m.setFields(pos, current.With(fieldType, 0))
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) setFields(pos geometry.Point, fields Fields) {
+	if fields.IsEmpty() {
+		delete(m.fields, pos)
+		return
+	}
+	m.fields[pos] = fields
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) FieldsAt(pos geometry.Point) Fields {
+	return m.fields[pos]
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) IsFieldAt(pos geometry.Point, fieldType FieldType) bool {
+	return m.fields[pos].Get(fieldType) > 0
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) HasFields() bool {
+	return len(m.fields) > 0
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) IsSmokeBlockingSightAt(pos geometry.Point) bool {
+	return m.fields[pos].Smoke >= SmokeOpaqueIntensity
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) GetAllFieldPositions() []geometry.Point {
+	positions := make([]geometry.Point, 0, len(m.fields))
+	for pos := range m.fields {
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// isOpenForAir is true for cells that smoke and gas can drift into.
+// Closed doors and walls stop them, open doors don't.
+func (m *GridMap[ActorType, ItemType, ObjectType]) isOpenForAir(p geometry.Point) bool {
+	if !m.Contains(p) {
+		return false
+	}
+	if objectAt, ok := m.TryGetObjectAt(p); ok && !objectAt.IsTransparent() {
+		return false
+	}
+	tile := m.GetCell(p).TileType
+	return tile.IsWalkable || tile.IsTransparent
+}
+
+// UpdateFields advances all environmental fields by one turn.
+// Fire burns down, produces smoke and spreads to flammable neighbors,
+// smoke and gas drift through open cells and slowly dissipate.
+// Returns the positions that caught fire during this update.
+func (m *GridMap[ActorType, ItemType, ObjectType]) UpdateFields(isFlammable func(p geometry.Point) bool) []geometry.Point {
+	if len(m.fields) == 0 {
+		return nil
+	}
+	next := make(map[geometry.Point]Fields, len(m.fields))
+	add := func(pos geometry.Point, fieldType FieldType, amount int) {
+		current := next[pos]
+		next[pos] = current.With(fieldType, current.Get(fieldType)+amount)
+	}
+
+	var ignited []geometry.Point
+	dissipation := 1
+	if m.meta.IsOutdoor {
+		dissipation = 2
+	}
+
+	for pos, fields := range m.fields {
+		if fields.Fire > 0 && !m.IsTileWithFlagAt(pos, TileFlagWater) {
+			burnDown := 2
+			if isFlammable(pos) {
+				burnDown = 1
+			}
+			add(pos, FieldFire, fields.Fire-burnDown)
+			add(pos, FieldSmoke, 2)
+
+			for _, neighbor := range m.NeighborsCardinal(pos, m.Contains) {
+				if m.fields[neighbor].Fire > 0 || next[neighbor].Fire > 0 || !isFlammable(neighbor) {
+					continue
+				}
+				if rand.Intn(100) < fields.Fire*8 {
+					add(neighbor, FieldFire, fields.Fire/2+2)
+					ignited = append(ignited, neighbor)
+				}
+			}
+		}
+
+		if fields.Smoke > 0 {
+			add(pos, FieldSmoke, m.driftInto(pos, fields.Smoke, FieldSmoke, add)-dissipation)
+		}
+
+		if fields.Gas > 0 {
+			remaining := m.driftInto(pos, fields.Gas, FieldGas, add)
+			if rand.Intn(3) == 0 {
+				remaining -= dissipation
+			}
+			add(pos, FieldGas, remaining)
+		}
+	}
+
+	for pos, fields := range next {
+		if fields.IsEmpty() {
+			delete(next, pos)
+		}
+	}
+	m.fields = next
+	return ignited
+}
+
+// driftInto moves a part of the intensity to neighbors with a lower concentration
+// and returns the amount that stays in the cell.
+func (m *GridMap[ActorType, ItemType, ObjectType]) driftInto(pos geometry.Point, intensity int, fieldType FieldType, add func(geometry.Point, FieldType, int)) int {
+	if intensity <= 1 {
+		return intensity
+	}
+	lowerNeighbors := m.NeighborsCardinal(pos, func(p geometry.Point) bool {
+		return m.isOpenForAir(p) && m.fields[p].Get(fieldType) < intensity-1
+	})
+	if len(lowerNeighbors) == 0 {
+		return intensity
+	}
+	rand.Shuffle(len(lowerNeighbors), func(i, j int) {
+		lowerNeighbors[i], lowerNeighbors[j] = lowerNeighbors[j], lowerNeighbors[i]
+	})
+	toShare := intensity / 2
+	for _, neighbor := range lowerNeighbors {
+		if toShare <= 0 {
+			break
+		}
+		add(neighbor, fieldType, 1)
+		toShare--
+		intensity--
+	}
+	return intensity
+}
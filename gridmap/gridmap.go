@@ -152,6 +152,7 @@ type GridMap[ActorType interface {
 	allObjects      []ObjectType
 
 	decals map[geometry.Point]int32
+	fields map[geometry.Point]Fields
 
 	playerSpawn geometry.Point
 
@@ -172,6 +173,8 @@ type GridMap[ActorType interface {
 	namedTrigger map[string]Trigger
 
 	namedPaths           map[string][]geometry.Point
+	patrolRoutes         map[string]PatrolRoute
+	namedAlarms          map[string]Alarm
 	cardinalMovementOnly bool
 
 	// LIGHTING
@@ -575,7 +578,10 @@ func NewEmptyMap[ActorType interface {
 		namedRects:          make(map[string]geometry.Rect),
 		namedTrigger:        make(map[string]Trigger),
 		namedPaths:          make(map[string][]geometry.Point),
+		patrolRoutes:        make(map[string]PatrolRoute),
+		namedAlarms:         make(map[string]Alarm),
 		decals:              make(map[geometry.Point]int32),
+		fields:              make(map[geometry.Point]Fields),
 		DynamicLights:       make(map[geometry.Point]*LightSource),
 		BakedLights:         make(map[geometry.Point]*LightSource),
 		lightfov:            geometry.NewFOV(geometry.NewRect(0, 0, width, height)),
@@ -657,6 +663,10 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) IsTransparent(p geometry.Poin
 		return false
 	}
 
+	if m.IsSmokeBlockingSightAt(p) {
+		return false
+	}
+
 	return m.GetCell(p).TileType.IsTransparent
 }
 
@@ -884,7 +894,7 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) IsWalkable(p geometry.Point)
 	return cellAt.TileType.IsWalkable
 }
 func (m *GridMap[ActorType, ItemType, ObjectType]) IsObviousHazardAt(p geometry.Point) bool {
-	return m.IsHazardousTileAt(p)
+	return m.IsHazardousTileAt(p) || m.IsFieldAt(p, FieldFire) || m.IsFieldAt(p, FieldGas)
 }
 func (m *GridMap[ActorType, ItemType, ObjectType]) IsWalkableFor(p geometry.Point, person ActorType) bool {
 	if !m.Contains(p) {
@@ -1420,16 +1430,21 @@ type Trigger struct {
 	Name    string
 	Bounds  geometry.Rect
 	OneShot bool
-}
 
-func (m *GridMap[ActorType, ItemType, ObjectType]) GetNamedTriggerAt(pos geometry.Point) (Trigger, bool) {
-	for _, trigger := range m.namedTrigger {
-		if trigger.Bounds.Contains(pos) {
-			return trigger, true
-		}
-	}
-	return Trigger{}, false
+	// Actor restricts who can set off the trigger:
+	// empty or "player" for the player only, "npc" for everyone else,
+	// "any" for all actors or the internal name of a specific actor.
+	Actor string
+	// Condition is an optional expression that must evaluate to true for the trigger to fire.
+	Condition string
+	// Each action is either the name of a script or an inline function call.
+	OnEnter    []string
+	OnLeave    []string
+	OnDwell    []string
+	DwellTurns int
+	HasFired   bool
 }
+
 func (m *GridMap[ActorType, ItemType, ObjectType]) SetTileIcon(pos geometry.Point, index textiles.TextIcon) {
 	m.cells[pos.Y*m.mapWidth+pos.X].TileType.Icon = index
 }
@@ -1452,6 +1467,17 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) AddNamedTrigger(name string,
 	m.namedTrigger[name] = rect
 }
 
+func (m *GridMap[ActorType, ItemType, ObjectType]) GetNamedTriggers() map[string]Trigger {
+	return m.namedTrigger
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) SetTriggerFired(triggerName string) {
+	if trigger, exists := m.namedTrigger[triggerName]; exists {
+		trigger.HasFired = true
+		m.namedTrigger[triggerName] = trigger
+	}
+}
+
 func (m *GridMap[ActorType, ItemType, ObjectType]) GetDisplayName() string {
 	return m.meta.DisplayName
 }
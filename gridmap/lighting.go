@@ -14,6 +14,7 @@ type LightSource struct {
 	Radius       int
 	Color        fxtools.HDRColor
 	MaxIntensity float64
+	Disabled     bool
 }
 
 func (s LightSource) ToRecord() []recfile.Field {
@@ -71,6 +72,22 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) IsBakedLightSource(pos geomet
 	return ok
 }
 
+// SetBakedLightEnabled switches a baked light on or off without removing it from the map.
+// It will automatically call UpdateBakedLights.
+func (m *GridMap[ActorType, ItemType, ObjectType]) SetBakedLightEnabled(pos geometry.Point, enabled bool) {
+	light, ok := m.BakedLights[pos]
+	if !ok || light.Disabled == !enabled {
+		return
+	}
+	light.Disabled = !enabled
+	m.UpdateBakedLights()
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) IsBakedLightEnabled(pos geometry.Point) bool {
+	light, ok := m.BakedLights[pos]
+	return ok && !light.Disabled
+}
+
 // MoveLightSource moves a light source to a new position. It will automatically call UpdateDynamicLights.
 func (m *GridMap[ActorType, ItemType, ObjectType]) MoveLightSource(lightSource *LightSource, to geometry.Point) {
 	if m.IsDynamicLightSource(to) {
@@ -175,6 +192,9 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) UpdateDynamicLights() {
 }
 
 func (m *GridMap[ActorType, ItemType, ObjectType]) UpdateBakedLights() {
+	for i := range m.cells {
+		m.cells[i].BakedLighting = fxtools.HDRColor{}
+	}
 	setLightAt := func(point geometry.Point, light fxtools.HDRColor) {
 		m.cells[point.X+point.Y*m.mapWidth].BakedLighting = light
 	}
@@ -189,6 +209,9 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) updateLightMap(lightSources m
 		return m.IsTransparent(p) && !m.IsActorAt(p)
 	}
 	for _, lightSource := range lightSources {
+		if lightSource.Disabled {
+			continue
+		}
 		for _, nodePos := range m.lightfov.SSCVisionMap(lightSource.Pos, lightSource.Radius, true, isTransparent) {
 
 			//for _, node := range m.lightfov.LightMap(&MapLighter[VictimType, ItemType, ObjectType]{gridmap: m, sources: lightSources}, []geometry.Point{lightSource.Pos}) {
@@ -0,0 +1,114 @@
+package gridmap
+
+import (
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"strconv"
+	"strings"
+)
+
+type PatrolWaypoint struct {
+	Position  geometry.Point
+	WaitTurns int
+	HasFacing bool
+	Facing    geometry.CompassDirection
+}
+
+// PatrolRoute is a named path of waypoints that NPCs walk along while they are unaware.
+// A looping route goes from the last waypoint back to the first,
+// a ping-pong route walks the waypoints in reverse order on the way back.
+type PatrolRoute struct {
+	Name      string
+	Waypoints []PatrolWaypoint
+	PingPong  bool
+}
+
+// NewPatrolRouteFromRecord reads a route from the objects of a map.
+// Every waypoint is a position, optionally followed by the turns to wait there and the direction to face, eg.
+//
+//	Waypoint: (37,6) 3 north
+func NewPatrolRouteFromRecord(record recfile.Record) PatrolRoute {
+	var route PatrolRoute
+	for _, field := range record {
+		switch strings.ToLower(field.Name) {
+		case "identifier", "route":
+			route.Name = field.Value
+		case "mode":
+			route.PingPong = strings.ToLower(field.Value) == "pingpong"
+		case "waypoint":
+			route.Waypoints = append(route.Waypoints, newPatrolWaypointFromString(field.Value))
+		}
+	}
+	return route
+}
+
+func newPatrolWaypointFromString(value string) PatrolWaypoint {
+	var waypoint PatrolWaypoint
+	parts := strings.Fields(value)
+	if len(parts) == 0 {
+		return waypoint
+	}
+	waypoint.Position, _ = geometry.NewPointFromEncodedString(parts[0])
+	if len(parts) > 1 {
+		waypoint.WaitTurns, _ = strconv.Atoi(parts[1])
+	}
+	if len(parts) > 2 {
+		waypoint.Facing, waypoint.HasFacing = directionFromString(parts[2])
+	}
+	return waypoint
+}
+
+func directionFromString(value string) (geometry.CompassDirection, bool) {
+	switch strings.ToLower(value) {
+	case "north", "n":
+		return geometry.North, true
+	case "northeast", "ne":
+		return geometry.NorthEast, true
+	case "east", "e":
+		return geometry.East, true
+	case "southeast", "se":
+		return geometry.SouthEast, true
+	case "south", "s":
+		return geometry.South, true
+	case "southwest", "sw":
+		return geometry.SouthWest, true
+	case "west", "w":
+		return geometry.West, true
+	case "northwest", "nw":
+		return geometry.NorthWest, true
+	}
+	return geometry.North, false
+}
+
+// NextWaypoint returns the index of the waypoint following current.
+// The direction is 1 or -1 and only changes on ping-pong routes.
+func (r PatrolRoute) NextWaypoint(current, direction int) (int, int) {
+	if len(r.Waypoints) < 2 {
+		return 0, direction
+	}
+	if direction == 0 {
+		direction = 1
+	}
+	next := current + direction
+	if next >= 0 && next < len(r.Waypoints) {
+		return next, direction
+	}
+	if !r.PingPong {
+		return 0, 1
+	}
+	direction = -direction
+	return current + direction, direction
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) AddPatrolRoute(route PatrolRoute) {
+	m.patrolRoutes[route.Name] = route
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) GetPatrolRoute(name string) (PatrolRoute, bool) {
+	route, exists := m.patrolRoutes[name]
+	return route, exists
+}
+
+func (m *GridMap[ActorType, ItemType, ObjectType]) GetPatrolRoutes() map[string]PatrolRoute {
+	return m.patrolRoutes
+}
@@ -0,0 +1,64 @@
+package gridmap
+
+import (
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"testing"
+)
+
+func routeWithWaypoints(count int, pingPong bool) PatrolRoute {
+	route := PatrolRoute{Name: "test", PingPong: pingPong}
+	for i := 0; i < count; i++ {
+		route.Waypoints = append(route.Waypoints, PatrolWaypoint{Position: geometry.Point{X: i, Y: 0}})
+	}
+	return route
+}
+
+func TestPatrolRouteNextWaypoint(t *testing.T) {
+	tests := []struct {
+		name          string
+		route         PatrolRoute
+		current       int
+		direction     int
+		wantNext      int
+		wantDirection int
+	}{
+		{"loop forward", routeWithWaypoints(3, false), 0, 1, 1, 1},
+		{"loop wraps around", routeWithWaypoints(3, false), 2, 1, 0, 1},
+		{"zero direction starts forward", routeWithWaypoints(3, false), 0, 0, 1, 1},
+		{"ping-pong forward", routeWithWaypoints(3, true), 1, 1, 2, 1},
+		{"ping-pong turns at the end", routeWithWaypoints(3, true), 2, 1, 1, -1},
+		{"ping-pong backward", routeWithWaypoints(3, true), 1, -1, 0, -1},
+		{"ping-pong turns at the start", routeWithWaypoints(3, true), 0, -1, 1, 1},
+		{"single waypoint", routeWithWaypoints(1, true), 0, 1, 0, 1},
+		{"empty route", routeWithWaypoints(0, false), 0, 1, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, direction := tt.route.NextWaypoint(tt.current, tt.direction)
+			if next != tt.wantNext || direction != tt.wantDirection {
+				t.Errorf("NextWaypoint(%d, %d) = (%d, %d), want (%d, %d)", tt.current, tt.direction, next, direction, tt.wantNext, tt.wantDirection)
+			}
+		})
+	}
+}
+
+func TestNewPatrolRouteFromRecord(t *testing.T) {
+	route := NewPatrolRouteFromRecord(recfile.Record{
+		{Name: "Identifier", Value: "guard_round"},
+		{Name: "Mode", Value: "PingPong"},
+		{Name: "Waypoint", Value: "(37,6) 3 north"},
+		{Name: "Waypoint", Value: "(40,6)"},
+	})
+	if route.Name != "guard_round" || !route.PingPong || len(route.Waypoints) != 2 {
+		t.Fatalf("unexpected route %+v", route)
+	}
+	first := route.Waypoints[0]
+	if first.Position != (geometry.Point{X: 37, Y: 6}) || first.WaitTurns != 3 || !first.HasFacing || first.Facing != geometry.North {
+		t.Errorf("unexpected first waypoint %+v", first)
+	}
+	second := route.Waypoints[1]
+	if second.Position != (geometry.Point{X: 40, Y: 6}) || second.WaitTurns != 0 || second.HasFacing {
+		t.Errorf("unexpected second waypoint %+v", second)
+	}
+}
@@ -235,6 +235,16 @@ func tryHandleAsPseudoObject[ActorType interface {
 		pos, _ := geometry.NewPointFromEncodedString(rec.FindValueForKeyIgnoreCase("position"))
 		newMap.AddNamedLocation(name, pos)
 		return true
+	case "trigger":
+		trigger := NewTriggerFromRecord(rec)
+		newMap.AddNamedTrigger(trigger.Name, trigger)
+		return true
+	case "patrolroute":
+		newMap.AddPatrolRoute(NewPatrolRouteFromRecord(rec))
+		return true
+	case "alarm":
+		newMap.AddAlarm(NewAlarmFromRecord(rec))
+		return true
 	}
 	return false
 }
@@ -129,6 +129,42 @@ func (m *GridMap[ActorType, ItemType, ObjectType]) Save(directory string) error
 		}
 	}
 
+	if len(m.fields) > 0 {
+		fieldFile := fxtools.MustCreate(path.Join(directory, "fields.bin"))
+		defer fieldFile.Close()
+		gobber = gob.NewEncoder(fieldFile)
+		if err = gobber.Encode(m.fields); err != nil {
+			return err
+		}
+	}
+
+	if len(m.namedTrigger) > 0 {
+		triggerFile := fxtools.MustCreate(path.Join(directory, "triggers.bin"))
+		defer triggerFile.Close()
+		gobber = gob.NewEncoder(triggerFile)
+		if err = gobber.Encode(m.namedTrigger); err != nil {
+			return err
+		}
+	}
+
+	if len(m.patrolRoutes) > 0 {
+		routeFile := fxtools.MustCreate(path.Join(directory, "patrolroutes.bin"))
+		defer routeFile.Close()
+		gobber = gob.NewEncoder(routeFile)
+		if err = gobber.Encode(m.patrolRoutes); err != nil {
+			return err
+		}
+	}
+
+	if len(m.namedAlarms) > 0 {
+		alarmFile := fxtools.MustCreate(path.Join(directory, "alarms.bin"))
+		defer alarmFile.Close()
+		gobber = gob.NewEncoder(alarmFile)
+		if err = gobber.Encode(m.namedAlarms); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -302,5 +338,53 @@ func Load[ActorType interface {
 		restoredMap.BakedLights = lights
 	}
 
+	if fxtools.FileExists(path.Join(directory, "fields.bin")) {
+		fieldFile := fxtools.MustOpen(path.Join(directory, "fields.bin"))
+		defer fieldFile.Close()
+		gobber = gob.NewDecoder(fieldFile)
+		var fields map[geometry.Point]Fields
+		err = gobber.Decode(&fields)
+		if err != nil {
+			panic(err)
+		}
+		restoredMap.fields = fields
+	}
+
+	if fxtools.FileExists(path.Join(directory, "triggers.bin")) {
+		triggerFile := fxtools.MustOpen(path.Join(directory, "triggers.bin"))
+		defer triggerFile.Close()
+		gobber = gob.NewDecoder(triggerFile)
+		var triggers map[string]Trigger
+		err = gobber.Decode(&triggers)
+		if err != nil {
+			panic(err)
+		}
+		restoredMap.namedTrigger = triggers
+	}
+
+	if fxtools.FileExists(path.Join(directory, "patrolroutes.bin")) {
+		routeFile := fxtools.MustOpen(path.Join(directory, "patrolroutes.bin"))
+		defer routeFile.Close()
+		gobber = gob.NewDecoder(routeFile)
+		var routes map[string]PatrolRoute
+		err = gobber.Decode(&routes)
+		if err != nil {
+			panic(err)
+		}
+		restoredMap.patrolRoutes = routes
+	}
+
+	if fxtools.FileExists(path.Join(directory, "alarms.bin")) {
+		alarmFile := fxtools.MustOpen(path.Join(directory, "alarms.bin"))
+		defer alarmFile.Close()
+		gobber = gob.NewDecoder(alarmFile)
+		var alarms map[string]Alarm
+		err = gobber.Decode(&alarms)
+		if err != nil {
+			panic(err)
+		}
+		restoredMap.namedAlarms = alarms
+	}
+
 	return restoredMap
 }
@@ -25,6 +25,7 @@ const ( // bitwise flags
 	TileFlagMountable
 	TileFlagCrawlable
 	TileFlagDestroyable
+	TileFlagFlammable
 )
 
 func (t TileFlags) Has(tag TileFlags) bool {
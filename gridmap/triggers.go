@@ -0,0 +1,70 @@
+package gridmap
+
+import (
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"strings"
+)
+
+// NewTriggerFromRecord reads a trigger region from the objects of a map.
+// Position is the top left and End the bottom right corner of the region, both inclusive.
+func NewTriggerFromRecord(record recfile.Record) Trigger {
+	var trigger Trigger
+	var topLeft, bottomRight geometry.Point
+	hasEnd := false
+	for _, field := range record {
+		switch strings.ToLower(field.Name) {
+		case "name":
+			trigger.Name = field.Value
+		case "position":
+			topLeft, _ = geometry.NewPointFromEncodedString(field.Value)
+		case "end":
+			bottomRight, _ = geometry.NewPointFromEncodedString(field.Value)
+			hasEnd = true
+		case "actor":
+			trigger.Actor = strings.ToLower(field.Value)
+		case "condition":
+			trigger.Condition = field.Value
+		case "onenter":
+			trigger.OnEnter = append(trigger.OnEnter, field.Value)
+		case "onleave":
+			trigger.OnLeave = append(trigger.OnLeave, field.Value)
+		case "ondwell":
+			trigger.OnDwell = append(trigger.OnDwell, field.Value)
+		case "dwellturns":
+			trigger.DwellTurns = field.AsInt()
+		case "oneshot":
+			trigger.OneShot = field.AsBool()
+		}
+	}
+	if !hasEnd {
+		bottomRight = topLeft
+	}
+	minX, maxX := min(topLeft.X, bottomRight.X), max(topLeft.X, bottomRight.X)
+	minY, maxY := min(topLeft.Y, bottomRight.Y), max(topLeft.Y, bottomRight.Y)
+	trigger.Bounds = geometry.NewRect(minX, minY, maxX+1, maxY+1)
+
+	if trigger.Name == "" {
+		trigger.Name = topLeft.Encode()
+	}
+	if len(trigger.OnDwell) > 0 && trigger.DwellTurns <= 0 {
+		trigger.DwellTurns = 1
+	}
+	return trigger
+}
+
+// IsActive is false for one-shot triggers that already went off.
+func (t Trigger) IsActive() bool {
+	return !t.OneShot || !t.HasFired
+}
+
+// GetNamedTriggersAt returns all active triggers covering the given position.
+func (m *GridMap[ActorType, ItemType, ObjectType]) GetNamedTriggersAt(pos geometry.Point) []Trigger {
+	var result []Trigger
+	for _, trigger := range m.namedTrigger {
+		if trigger.IsActive() && trigger.Bounds.Contains(pos) {
+			result = append(result, trigger)
+		}
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+package gridmap
+
+import (
+	"github.com/memmaker/go/geometry"
+	"github.com/memmaker/go/recfile"
+	"testing"
+)
+
+func TestNewTriggerFromRecord(t *testing.T) {
+	tests := []struct {
+		name           string
+		record         recfile.Record
+		wantName       string
+		wantActor      string
+		wantMin        geometry.Point
+		wantMax        geometry.Point
+		wantDwellTurns int
+		wantOneShot    bool
+	}{
+		{
+			name: "region with end",
+			record: recfile.Record{
+				{Name: "name", Value: "alley"},
+				{Name: "position", Value: "(2,3)"},
+				{Name: "end", Value: "(5,4)"},
+				{Name: "actor", Value: "NPC"},
+				{Name: "onenter", Value: "alley_enter"},
+			},
+			wantName:  "alley",
+			wantActor: "npc",
+			wantMin:   geometry.Point{X: 2, Y: 3},
+			wantMax:   geometry.Point{X: 6, Y: 5},
+		},
+		{
+			name: "corners in any order",
+			record: recfile.Record{
+				{Name: "name", Value: "swapped"},
+				{Name: "position", Value: "(5,4)"},
+				{Name: "end", Value: "(2,3)"},
+			},
+			wantName: "swapped",
+			wantMin:  geometry.Point{X: 2, Y: 3},
+			wantMax:  geometry.Point{X: 6, Y: 5},
+		},
+		{
+			name: "single tile without name",
+			record: recfile.Record{
+				{Name: "position", Value: "(7,1)"},
+				{Name: "oneshot", Value: "true"},
+			},
+			wantName:    geometry.Point{X: 7, Y: 1}.Encode(),
+			wantMin:     geometry.Point{X: 7, Y: 1},
+			wantMax:     geometry.Point{X: 8, Y: 2},
+			wantOneShot: true,
+		},
+		{
+			name: "dwell action without turns",
+			record: recfile.Record{
+				{Name: "name", Value: "bench"},
+				{Name: "position", Value: "(1,1)"},
+				{Name: "ondwell", Value: "sit_down"},
+			},
+			wantName:       "bench",
+			wantMin:        geometry.Point{X: 1, Y: 1},
+			wantMax:        geometry.Point{X: 2, Y: 2},
+			wantDwellTurns: 1,
+		},
+		{
+			name: "dwell action with turns",
+			record: recfile.Record{
+				{Name: "name", Value: "vault"},
+				{Name: "position", Value: "(1,1)"},
+				{Name: "ondwell", Value: "alarm"},
+				{Name: "dwellturns", Value: "5"},
+			},
+			wantName:       "vault",
+			wantMin:        geometry.Point{X: 1, Y: 1},
+			wantMax:        geometry.Point{X: 2, Y: 2},
+			wantDwellTurns: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger := NewTriggerFromRecord(tt.record)
+			if trigger.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", trigger.Name, tt.wantName)
+			}
+			if trigger.Actor != tt.wantActor {
+				t.Errorf("Actor = %q, want %q", trigger.Actor, tt.wantActor)
+			}
+			if trigger.Bounds.Min != tt.wantMin || trigger.Bounds.Max != tt.wantMax {
+				t.Errorf("Bounds = %v-%v, want %v-%v", trigger.Bounds.Min, trigger.Bounds.Max, tt.wantMin, tt.wantMax)
+			}
+			if trigger.DwellTurns != tt.wantDwellTurns {
+				t.Errorf("DwellTurns = %d, want %d", trigger.DwellTurns, tt.wantDwellTurns)
+			}
+			if trigger.OneShot != tt.wantOneShot {
+				t.Errorf("OneShot = %v, want %v", trigger.OneShot, tt.wantOneShot)
+			}
+			if !trigger.IsActive() {
+				t.Errorf("a new trigger should be active")
+			}
+		})
+	}
+}
+
+func TestTriggerIsActive(t *testing.T) {
+	tests := []struct {
+		oneShot, hasFired, want bool
+	}{
+		{false, false, true},
+		{false, true, true},
+		{true, false, true},
+		{true, true, false},
+	}
+	for _, tt := range tests {
+		trigger := Trigger{OneShot: tt.oneShot, HasFired: tt.hasFired}
+		if got := trigger.IsActive(); got != tt.want {
+			t.Errorf("IsActive() with OneShot=%v HasFired=%v = %v, want %v", tt.oneShot, tt.hasFired, got, tt.want)
+		}
+	}
+}
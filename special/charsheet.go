@@ -22,6 +22,9 @@ import (
 // 7. Party members
 const SkillCap = 200
 
+// SkillProgressPerPoint is the progress needed for a skill point, when learning by doing.
+const SkillProgressPerPoint = 100
+
 type Stat int
 
 func (s Stat) ToShortString() string {
@@ -322,7 +325,7 @@ func skillFromShortString(name string) Skill {
 		return RangedCombat
 	case "meleecombat":
 		return MeleeCombat
-	case "biology":
+	case "biology", "doctor":
 		return Biology
 	case "stealth":
 		return Stealth
@@ -415,6 +418,7 @@ func NewCharSheet() *CharSheet {
 		availableStatPoints:    0,
 		derivedStatAdjustments: make(map[DerivedStat]int),
 		skillAdjustments:       make(map[Skill]int),
+		skillProgress:          make(map[Skill]int),
 		taggedSkills:           make(map[Skill]bool),
 		level:                  1,
 	}
@@ -433,9 +437,12 @@ type CharSheet struct {
 
 	derivedStatAdjustments map[DerivedStat]int
 	skillAdjustments       map[Skill]int
+	skillProgress          map[Skill]int
 
 	taggedSkills map[Skill]bool
 
+	traits []Trait
+
 	hitPointsCurrent int
 
 	actionPointsCurrent int
@@ -486,6 +493,12 @@ func (cs *CharSheet) GobEncode() ([]byte, error) {
 	if err := encoder.Encode(cs.actionPointsCurrent); err != nil {
 		return nil, err
 	}
+	if err := encoder.Encode(cs.traits); err != nil {
+		return nil, err
+	}
+	if err := encoder.Encode(cs.skillProgress); err != nil {
+		return nil, err
+	}
 
 	return buf.Bytes(), nil
 }
@@ -526,6 +539,15 @@ func (cs *CharSheet) GobDecode(data []byte) error {
 	if err := decoder.Decode(&cs.actionPointsCurrent); err != nil {
 		return err
 	}
+	if err := decoder.Decode(&cs.traits); err != nil {
+		return err
+	}
+	if err := decoder.Decode(&cs.skillProgress); err != nil {
+		return err
+	}
+	if cs.skillProgress == nil {
+		cs.skillProgress = make(map[Skill]int)
+	}
 
 	return nil
 }
@@ -707,97 +729,52 @@ func (cs *CharSheet) IsAlive() bool {
 }
 
 func (cs *CharSheet) onRetrieveStatHook(stat Stat, value int) int {
-	if cs.getStatMods != nil {
-		mods := cs.getStatMods(stat)
-
-		slices.SortStableFunc(mods, func(i, j Modifier) int {
-			return cmp.Compare(i.SortOrder(), j.SortOrder())
-		})
-
-		for _, mod := range mods {
-			value = mod.Apply(value)
-		}
-		return value
-	}
+	value, _ = cs.getModifiedStatWithInfo(stat, value)
 	return value
 }
 
 func (cs *CharSheet) getModifiedStatWithInfo(stat Stat, value int) (int, []Modifier) {
+	mods := cs.getTraitStatModifiers(stat)
 	if cs.getStatMods != nil {
-		mods := cs.getStatMods(stat)
-
-		slices.SortStableFunc(mods, func(i, j Modifier) int {
-			return cmp.Compare(i.SortOrder(), j.SortOrder())
-		})
-
-		for _, mod := range mods {
-			value = mod.Apply(value)
-		}
-		return value, mods
+		mods = append(mods, cs.getStatMods(stat)...)
 	}
-	return value, nil
+	return applyModifiers(value, mods)
 }
 func (cs *CharSheet) getModifiedDerivedStatWithInfo(ds DerivedStat, value int) (int, []Modifier) {
+	mods := cs.getTraitDerivedStatModifiers(ds)
 	if cs.getDerivedStatMods != nil {
-		mods := cs.getDerivedStatMods(ds)
-
-		slices.SortStableFunc(mods, func(i, j Modifier) int {
-			return cmp.Compare(i.SortOrder(), j.SortOrder())
-		})
-
-		for _, mod := range mods {
-			value = mod.Apply(value)
-		}
-		return value, mods
+		mods = append(mods, cs.getDerivedStatMods(ds)...)
 	}
-	return value, nil
+	return applyModifiers(value, mods)
 }
 func (cs *CharSheet) onRetrieveDerivedStatHook(ds DerivedStat, value int) int {
-	if cs.getDerivedStatMods != nil {
-		mods := cs.getDerivedStatMods(ds)
-
-		slices.SortStableFunc(mods, func(i, j Modifier) int {
-			return cmp.Compare(i.SortOrder(), j.SortOrder())
-		})
-
-		for _, mod := range mods {
-			value = mod.Apply(value)
-		}
-		return value
-	}
+	value, _ = cs.getModifiedDerivedStatWithInfo(ds, value)
 	return value
 }
 
 func (cs *CharSheet) onRetrieveSkillHook(skill Skill, value int) int {
-	if cs.getSkillMods != nil {
-		mods := cs.getSkillMods(skill)
-
-		slices.SortStableFunc(mods, func(i, j Modifier) int {
-			return cmp.Compare(i.SortOrder(), j.SortOrder())
-		})
-
-		for _, mod := range mods {
-			value = mod.Apply(value)
-		}
-		return value
-	}
+	value, _ = cs.getModifiedSkillWithInfo(skill, value)
 	return value
 }
 
 func (cs *CharSheet) getModifiedSkillWithInfo(skill Skill, value int) (int, []Modifier) {
+	mods := cs.getTraitSkillModifiers(skill)
 	if cs.getSkillMods != nil {
-		mods := cs.getSkillMods(skill)
+		mods = append(mods, cs.getSkillMods(skill)...)
+	}
+	return applyModifiers(value, mods)
+}
 
-		slices.SortStableFunc(mods, func(i, j Modifier) int {
-			return cmp.Compare(i.SortOrder(), j.SortOrder())
-		})
+// applyModifiers applies the modifiers from the traits and the handlers in their sort order.
+func applyModifiers(value int, mods []Modifier) (int, []Modifier) {
+	slices.SortStableFunc(mods, func(i, j Modifier) int {
+		return cmp.Compare(i.SortOrder(), j.SortOrder())
+	})
 
-		for _, mod := range mods {
-			value = mod.Apply(value)
-		}
-		return value, mods
+	for _, mod := range mods {
+		value = mod.Apply(value)
 	}
-	return value, nil
+	return value, mods
 }
 
 func (cs *CharSheet) GetHitPointsMax() int {
@@ -914,6 +891,9 @@ func (cs *CharSheet) ToRecord() recfile.Record {
 		recfile.Field{Name: "HitPoints", Value: recfile.IntStr(cs.GetHitPoints())},
 		recfile.Field{Name: "ActionPoints", Value: recfile.IntStr(cs.GetActionPoints())},
 	}
+	for _, trait := range cs.traits {
+		record = append(record, recfile.Field{Name: "Trait", Value: trait.Name})
+	}
 	// add skills
 	for skillNo := 0; skillNo < int(SkillCount); skillNo++ {
 		skill := Skill(skillNo)
@@ -1080,3 +1060,25 @@ func (cs *CharSheet) AddSkillPointsTo(skill Skill, increase int) {
 	}
 	cs.skillAdjustments[skill] = cs.skillAdjustments[skill] + increase
 }
+
+// AddSkillProgress is used for learning by doing. The progress gained shrinks, the closer the skill gets to the SkillCap.
+// Every SkillProgressPerPoint of progress are turned into a skill point. Returns the number of skill points gained.
+func (cs *CharSheet) AddSkillProgress(skill Skill, amount int) int {
+	if amount <= 0 || cs.IsSkillAtCap(skill) {
+		return 0
+	}
+	remaining := SkillCap - cs.GetUnmodifiedSkill(skill)
+	progress := cs.skillProgress[skill] + max(1, amount*remaining/SkillCap)
+	points := min(remaining, progress/SkillProgressPerPoint)
+	cs.skillProgress[skill] = progress % SkillProgressPerPoint
+	if points > 0 {
+		cs.AddSkillPointsTo(skill, points)
+		cs.onSkillChanged(skill)
+	}
+	return points
+}
+
+// GetSkillProgress returns the progress towards the next skill point in percent.
+func (cs *CharSheet) GetSkillProgress(skill Skill) int {
+	return cs.skillProgress[skill] * 100 / SkillProgressPerPoint
+}
@@ -0,0 +1,43 @@
+package special
+
+import (
+	"testing"
+)
+
+func TestAddSkillProgress(t *testing.T) {
+	tests := []struct {
+		name          string
+		startSkill    int
+		startProgress int
+		amount        int
+		wantPoints    int
+		wantSkill     int
+		wantProgress  int
+	}{
+		{"full gain at zero", 0, 0, 100, 1, 1, 0},
+		{"several points at once", 0, 0, 450, 4, 4, 50},
+		{"half gain at half the cap", 100, 0, 100, 0, 100, 50},
+		{"quarter gain near the cap", 150, 0, 100, 0, 150, 25},
+		{"at least one progress right below the cap", 199, 0, 100, 0, 199, 1},
+		{"leftover progress completes a point", 199, 99, 100, 1, 200, 0},
+		{"never beyond the cap", 195, 0, 40000, 5, 200, 0},
+		{"nothing at the cap", 200, 0, 100, 0, 200, 0},
+		{"nothing for no effort", 0, 0, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sheet := NewCharSheet()
+			sheet.SetSkillAbsoluteValue(MeleeCombat, tt.startSkill)
+			sheet.skillProgress[MeleeCombat] = tt.startProgress
+			if points := sheet.AddSkillProgress(MeleeCombat, tt.amount); points != tt.wantPoints {
+				t.Errorf("AddSkillProgress(%d) = %d points, want %d", tt.amount, points, tt.wantPoints)
+			}
+			if skill := sheet.GetUnmodifiedSkill(MeleeCombat); skill != tt.wantSkill {
+				t.Errorf("skill = %d, want %d", skill, tt.wantSkill)
+			}
+			if progress := sheet.GetSkillProgress(MeleeCombat); progress != tt.wantProgress {
+				t.Errorf("progress = %d%%, want %d%%", progress, tt.wantProgress)
+			}
+		})
+	}
+}
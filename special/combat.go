@@ -6,6 +6,8 @@ type BodyPart int
 
 var HumanBodyParts = BodyStructure{Body, Eyes, Head, Arms, Groin, Legs}
 
+var MechanicalBodyParts = BodyStructure{Core, Sensors, Weapons, Mobility}
+
 const (
 	Body BodyPart = iota
 	Eyes
@@ -13,6 +15,10 @@ const (
 	Arms
 	Groin
 	Legs
+	Core
+	Sensors
+	Weapons
+	Mobility
 )
 
 func (b BodyPart) AimPenalty() int {
@@ -29,6 +35,14 @@ func (b BodyPart) AimPenalty() int {
 		return -30
 	case Legs:
 		return -20
+	case Core:
+		return 0
+	case Sensors:
+		return -50
+	case Weapons:
+		return -30
+	case Mobility:
+		return -20
 	}
 	return 0
 }
@@ -47,6 +61,14 @@ func (b BodyPart) String() string {
 		return "Groin"
 	case Legs:
 		return "Legs"
+	case Core:
+		return "Core"
+	case Sensors:
+		return "Sensors"
+	case Weapons:
+		return "Weapons"
+	case Mobility:
+		return "Mobility"
 	}
 	return "Unknown"
 }
@@ -66,6 +88,14 @@ func (b BodyPart) DamageForCrippled(maxHitpointsOfActor int) int {
 		return maxHitpointsOfActor / 6 // 5, 13, 25, 40
 	case Legs:
 		return maxHitpointsOfActor / 3 // 10, 26, 50, 80
+	case Core:
+		return maxHitpointsOfActor
+	case Sensors:
+		return maxHitpointsOfActor / 4
+	case Weapons:
+		return maxHitpointsOfActor / 3
+	case Mobility:
+		return maxHitpointsOfActor / 3
 	}
 	return maxHitpointsOfActor
 }
@@ -0,0 +1,128 @@
+package special
+
+import "math/rand"
+
+// CriticalEffect is a bit set of the consequences of a critical hit, besides the extra damage.
+type CriticalEffect uint16
+
+const (
+	CritKnockDown CriticalEffect = 1 << iota
+	CritKnockOut
+	CritBlind
+	CritDropWeapon
+	CritBypassArmor
+	CritCripple
+	CritInstantDeath
+)
+
+func (e CriticalEffect) Has(effect CriticalEffect) bool {
+	return e&effect != 0
+}
+
+type CriticalSeverity int
+
+const (
+	SeverityMinor CriticalSeverity = iota
+	SeverityModerate
+	SeveritySevere
+	SeverityDeadly
+)
+
+// CriticalHit is one entry of the critical tables.
+// The message is written with the victim as the object, eg. "The hit to the head knocks %s down".
+type CriticalHit struct {
+	DamagePercent int
+	Effects       CriticalEffect
+	Message       string
+}
+
+// CriticalChanceBonus makes aimed attacks at small body parts more likely to be critical.
+func (b BodyPart) CriticalChanceBonus() int {
+	return -b.AimPenalty() / 4
+}
+
+// RollCriticalSeverity rolls on the critical table, the bonus shifts the roll towards the worse outcomes.
+func RollCriticalSeverity(bonus int) CriticalSeverity {
+	roll := rand.Intn(100) + 1 + bonus
+	switch {
+	case roll <= 40:
+		return SeverityMinor
+	case roll <= 75:
+		return SeverityModerate
+	case roll <= 95:
+		return SeveritySevere
+	}
+	return SeverityDeadly
+}
+
+// CriticalHitFor looks up the critical table of the body part.
+func (b BodyPart) CriticalHitFor(severity CriticalSeverity) CriticalHit {
+	table, exists := criticalTables[b]
+	if !exists {
+		table = criticalTables[Body]
+	}
+	return table[severity]
+}
+
+var criticalTables = map[BodyPart][4]CriticalHit{
+	Body: {
+		{DamagePercent: 150, Message: "A solid hit to the body staggers %s"},
+		{DamagePercent: 200, Effects: CritKnockDown, Message: "The hit to the body knocks %s down"},
+		{DamagePercent: 200, Effects: CritBypassArmor | CritKnockDown, Message: "The hit slips past the armor and knocks %s down"},
+		{DamagePercent: 300, Effects: CritBypassArmor | CritKnockOut, Message: "The hit to the gut knocks the wind out of %s"},
+	},
+	Eyes: {
+		{DamagePercent: 150, Effects: CritBlind, Message: "A graze across the eyes blinds %s for a moment"},
+		{DamagePercent: 200, Effects: CritBlind, Message: "The hit to the eyes blinds %s"},
+		{DamagePercent: 200, Effects: CritBlind | CritCripple, Message: "The hit to the eyes leaves %s half blind"},
+		{DamagePercent: 300, Effects: CritInstantDeath, Message: "The hit through the eye kills %s instantly"},
+	},
+	Head: {
+		{DamagePercent: 200, Effects: CritKnockDown, Message: "The hit to the head knocks %s down"},
+		{DamagePercent: 200, Effects: CritKnockOut, Message: "The blow to the head knocks %s unconscious"},
+		{DamagePercent: 300, Effects: CritBypassArmor | CritKnockOut | CritCripple, Message: "The hit to the head sends %s to the ground with a cracked skull"},
+		{DamagePercent: 300, Effects: CritInstantDeath, Message: "The hit to the head kills %s instantly"},
+	},
+	Arms: {
+		{DamagePercent: 150, Effects: CritDropWeapon, Message: "The hit to the arm makes %s drop the weapon"},
+		{DamagePercent: 200, Effects: CritCripple, Message: "The hit to the arm cripples %s"},
+		{DamagePercent: 200, Effects: CritCripple | CritDropWeapon, Message: "The hit to the arm cripples %s and knocks the weapon away"},
+		{DamagePercent: 300, Effects: CritBypassArmor | CritCripple | CritDropWeapon, Message: "The hit shatters the bones in the arm and knocks the weapon away from %s"},
+	},
+	Groin: {
+		{DamagePercent: 150, Effects: CritKnockDown, Message: "The hit to the groin doubles %s over"},
+		{DamagePercent: 200, Effects: CritBypassArmor | CritKnockDown, Message: "The hit to the groin sends %s to the ground"},
+		{DamagePercent: 200, Effects: CritKnockOut, Message: "The hit to the groin makes %s pass out from the pain"},
+		{DamagePercent: 300, Effects: CritKnockOut | CritCripple, Message: "The hit to the groin leaves %s writhing on the ground"},
+	},
+	Legs: {
+		{DamagePercent: 150, Effects: CritKnockDown, Message: "The hit to the leg knocks %s off balance"},
+		{DamagePercent: 200, Effects: CritCripple, Message: "The hit to the leg cripples %s"},
+		{DamagePercent: 200, Effects: CritCripple | CritKnockDown, Message: "The hit to the leg cripples and fells %s"},
+		{DamagePercent: 300, Effects: CritBypassArmor | CritCripple | CritKnockDown, Message: "The hit shatters the knee and brings down %s"},
+	},
+	Core: {
+		{DamagePercent: 150, Message: "The hit to the core rattles %s"},
+		{DamagePercent: 200, Effects: CritBypassArmor, Message: "The hit pierces the plating of %s"},
+		{DamagePercent: 200, Effects: CritBypassArmor | CritKnockOut, Message: "The hit to the core short-circuits %s"},
+		{DamagePercent: 300, Effects: CritInstantDeath, Message: "The hit to the core destroys %s"},
+	},
+	Sensors: {
+		{DamagePercent: 150, Effects: CritBlind, Message: "The hit to the sensors blinds %s for a moment"},
+		{DamagePercent: 200, Effects: CritBlind, Message: "The hit to the sensors blinds %s"},
+		{DamagePercent: 200, Effects: CritBlind | CritCripple, Message: "The hit smashes the sensors of %s"},
+		{DamagePercent: 300, Effects: CritBypassArmor | CritBlind | CritCripple, Message: "The hit tears the sensors out of %s"},
+	},
+	Weapons: {
+		{DamagePercent: 150, Message: "The hit to the weapon mount jolts %s"},
+		{DamagePercent: 200, Effects: CritCripple, Message: "The hit to the weapon mount jams %s"},
+		{DamagePercent: 200, Effects: CritBypassArmor | CritCripple, Message: "The hit wrecks the weapon mount of %s"},
+		{DamagePercent: 300, Effects: CritBypassArmor | CritCripple | CritKnockOut, Message: "The hit blows the weapon mount off %s"},
+	},
+	Mobility: {
+		{DamagePercent: 150, Message: "The hit to the drive jolts %s"},
+		{DamagePercent: 200, Effects: CritCripple, Message: "The hit to the drive cripples %s"},
+		{DamagePercent: 200, Effects: CritBypassArmor | CritCripple, Message: "The hit tears through the drive of %s"},
+		{DamagePercent: 300, Effects: CritCripple | CritKnockOut, Message: "The hit wrecks the drive and shuts down %s"},
+	},
+}
@@ -0,0 +1,40 @@
+package special
+
+import (
+	"testing"
+)
+
+func TestRollCriticalSeverity(t *testing.T) {
+	tests := []struct {
+		name   string
+		bonus  int
+		wantLo CriticalSeverity
+		wantHi CriticalSeverity
+	}{
+		{"huge malus is always minor", -100, SeverityMinor, SeverityMinor},
+		{"huge bonus is always deadly", 100, SeverityDeadly, SeverityDeadly},
+		{"no bonus covers the whole table", 0, SeverityMinor, SeverityDeadly},
+		{"a bonus of 40 is never minor", 40, SeverityModerate, SeverityDeadly},
+		{"a malus of 40 is never deadly", -40, SeverityMinor, SeveritySevere},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				severity := RollCriticalSeverity(tt.bonus)
+				if severity < tt.wantLo || severity > tt.wantHi {
+					t.Fatalf("RollCriticalSeverity(%d) = %d, want between %d and %d", tt.bonus, severity, tt.wantLo, tt.wantHi)
+				}
+			}
+		})
+	}
+}
+
+func TestCriticalHitForFallsBackToBody(t *testing.T) {
+	for severity := SeverityMinor; severity <= SeverityDeadly; severity++ {
+		got := BodyPart(255).CriticalHitFor(severity)
+		want := Body.CriticalHitFor(severity)
+		if got != want {
+			t.Errorf("CriticalHitFor(%d) on an unknown part = %+v, want %+v", severity, got, want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package special
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxTraits is the number of traits a character can pick at creation.
+const MaxTraits = 2
+
+// TraitRule is a game rule a trait can change. The value of a rule is a percentage.
+type TraitRule int
+
+const (
+	RuleMovementTime TraitRule = iota
+	RuleDrugPrice
+	RuleDrugDuration
+	RuleAddictionChance
+)
+
+func (r TraitRule) String() string {
+	switch r {
+	case RuleMovementTime:
+		return "Movement Time"
+	case RuleDrugPrice:
+		return "Drug Prices"
+	case RuleDrugDuration:
+		return "Drug Duration"
+	case RuleAddictionChance:
+		return "Addiction Chance"
+	}
+	return ""
+}
+
+func TraitRuleFromString(name string) TraitRule {
+	name = strings.ReplaceAll(strings.ToLower(name), "_", "")
+	switch name {
+	case "movementtime":
+		return RuleMovementTime
+	case "drugprice":
+		return RuleDrugPrice
+	case "drugduration":
+		return RuleDrugDuration
+	case "addictionchance":
+		return RuleAddictionChance
+	}
+	panic(fmt.Sprintf("invalid trait rule: '%s'", name))
+	return -1
+}
+
+// Trait is a permanent quirk of a character, usually a bonus paired with a penalty.
+// The fields are exported, so the traits can be saved with the CharSheet.
+type Trait struct {
+	Name            string
+	Description     string
+	StatMods        map[Stat]int
+	DerivedStatMods map[DerivedStat]int
+	SkillMods       map[Skill]int
+	RuleMods        map[TraitRule]int
+}
+
+func NewTrait(name string) Trait {
+	return Trait{
+		Name:            name,
+		StatMods:        make(map[Stat]int),
+		DerivedStatMods: make(map[DerivedStat]int),
+		SkillMods:       make(map[Skill]int),
+		RuleMods:        make(map[TraitRule]int),
+	}
+}
+
+// Effects lists the changes of the trait, eg. "Speed: +2" or "Drug Prices: -50%".
+func (t Trait) Effects() []string {
+	var effects []string
+	for stat := Stat(0); stat < StatCount; stat++ {
+		if value, exists := t.StatMods[stat]; exists {
+			effects = append(effects, fmt.Sprintf("%s: %+d", stat.String(), value))
+		}
+	}
+	for ds := DerivedStat(0); ds < DerivedStatCount; ds++ {
+		if value, exists := t.DerivedStatMods[ds]; exists {
+			effects = append(effects, fmt.Sprintf("%s: %+d", ds.String(), value))
+		}
+	}
+	for skill := Skill(0); skill < SkillCount; skill++ {
+		if value, exists := t.SkillMods[skill]; exists {
+			effects = append(effects, fmt.Sprintf("%s: %+d%%", skill.String(), value))
+		}
+	}
+	for rule := RuleMovementTime; rule <= RuleAddictionChance; rule++ {
+		if value, exists := t.RuleMods[rule]; exists {
+			effects = append(effects, fmt.Sprintf("%s: %+d%%", rule.String(), value))
+		}
+	}
+	return effects
+}
+
+// TraitModifier is the effect of a trait on a stat, derived stat or skill. It never runs out.
+type TraitModifier struct {
+	Trait     string
+	Modifier  int
+	IsPercent bool
+}
+
+func (t TraitModifier) Description() string {
+	if t.IsPercent {
+		return fmt.Sprintf("%s (Trait): %+d%%", t.Trait, t.Modifier)
+	}
+	return fmt.Sprintf("%s (Trait): %+d", t.Trait, t.Modifier)
+}
+
+func (t TraitModifier) Apply(i int) int {
+	return i + t.Modifier
+}
+
+func (t TraitModifier) IsPersistent() bool {
+	return true
+}
+
+func (t TraitModifier) SortOrder() int {
+	return 1
+}
+
+func (cs *CharSheet) GetTraits() []Trait {
+	return cs.traits
+}
+
+func (cs *CharSheet) HasTrait(name string) bool {
+	for _, trait := range cs.traits {
+		if trait.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (cs *CharSheet) GetTraitCount() int {
+	return len(cs.traits)
+}
+
+func (cs *CharSheet) AddTrait(trait Trait) {
+	if cs.GetTraitCount() >= MaxTraits || cs.HasTrait(trait.Name) {
+		return
+	}
+	cs.traits = append(cs.traits, trait)
+}
+
+func (cs *CharSheet) RemoveTrait(name string) {
+	for i, trait := range cs.traits {
+		if trait.Name == name {
+			cs.traits = append(cs.traits[:i], cs.traits[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetTraitRule is the sum of the percentages all traits of the character add to the rule.
+func (cs *CharSheet) GetTraitRule(rule TraitRule) int {
+	total := 0
+	for _, trait := range cs.traits {
+		total += trait.RuleMods[rule]
+	}
+	return total
+}
+
+// ApplyTraitRule changes the value by the percentage the traits add to the rule.
+func (cs *CharSheet) ApplyTraitRule(rule TraitRule, value int) int {
+	percent := cs.GetTraitRule(rule)
+	if percent == 0 {
+		return value
+	}
+	return max(0, value+(value*percent)/100)
+}
+
+func (cs *CharSheet) getTraitStatModifiers(stat Stat) []Modifier {
+	var mods []Modifier
+	for _, trait := range cs.traits {
+		if value, exists := trait.StatMods[stat]; exists {
+			mods = append(mods, TraitModifier{Trait: trait.Name, Modifier: value})
+		}
+	}
+	return mods
+}
+
+func (cs *CharSheet) getTraitDerivedStatModifiers(ds DerivedStat) []Modifier {
+	var mods []Modifier
+	for _, trait := range cs.traits {
+		if value, exists := trait.DerivedStatMods[ds]; exists {
+			mods = append(mods, TraitModifier{Trait: trait.Name, Modifier: value})
+		}
+	}
+	return mods
+}
+
+func (cs *CharSheet) getTraitSkillModifiers(skill Skill) []Modifier {
+	var mods []Modifier
+	for _, trait := range cs.traits {
+		if value, exists := trait.SkillMods[skill]; exists {
+			mods = append(mods, TraitModifier{Trait: trait.Name, Modifier: value, IsPercent: true})
+		}
+	}
+	return mods
+}